@@ -0,0 +1,17 @@
+// Command gomapper-vet reports gomapper Map, MapTo, MapAs, MapParallel, MapSeq and MapChan calls without
+// a registered route.
+// Calls without routes registered in their package and packages it imports are reported in main packages
+// importing them, -main=false reports them in their packages.
+// It can be run standalone or with go vet:
+//
+//	go vet -vettool=$(which gomapper-vet) ./...
+package main
+
+import (
+	"github.com/insei/gomapper/analysis/routecheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(routecheck.Analyzer)
+}
@@ -0,0 +1,667 @@
+// Package routecheck defines the analyzer reporting Map, MapTo, MapAs, MapParallel, MapSeq and MapChan calls
+// without a registered route.
+//
+// Routes registered with AddRoute, AddConverter, AutoRoute, AutoRouteBidirectional, AddPolymorphicRoute,
+// AutoMapRoute and AutoValuesRoute and wrapper types registered with AddWrapper are exported as facts of
+// registering packages. Calls without routes registered in their package and packages it imports are exported
+// as facts too and reported in main packages importing them, where routes registered in the whole program,
+// i.e. in main or wiring packages, are known. With -main=false calls are reported in their packages.
+// Routes between slices, routes from pointers, from interfaces implemented by the source type and built-in routes
+// of sql.Null types and registered wrappers are resolved like at runtime. Calls with the source of the interface
+// type are not checked, because routes are resolved by the dynamic type of the source, and calls in bodies
+// of if statements checking HasRoute are not checked too.
+package routecheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const gomapperPath = "github.com/insei/gomapper"
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "gomapperroutes",
+	Doc:       "report gomapper Map, MapTo, MapAs, MapParallel, MapSeq and MapChan calls without a registered route",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(routesFact), new(pendingFact)},
+}
+
+// reportInMain reports calls of other packages in main packages importing them.
+var reportInMain bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&reportInMain, "main", true,
+		"report calls without routes in main packages importing them, so routes registered in main and wiring packages are found")
+}
+
+// routesFact contains routes and wrapper types registered in the package.
+type routesFact struct {
+	Routes   []registeredRoute
+	Wrappers []registeredWrapper
+}
+
+func (*routesFact) AFact() {}
+
+func (f *routesFact) String() string {
+	return "routes"
+}
+
+// pendingFact contains calls of the package without routes registered in the package and packages it imports.
+type pendingFact struct {
+	Calls []pendingCall
+}
+
+func (*pendingFact) AFact() {}
+
+func (f *pendingFact) String() string {
+	return "pending calls"
+}
+
+// registeredRoute is the registered route, types are identified by qualified type strings.
+type registeredRoute struct {
+	Source string
+	Dest   string
+	// InterfacePkg and InterfaceName identify the named interface source type.
+	InterfacePkg  string
+	InterfaceName string
+}
+
+// registeredWrapper is the wrapper type registered with AddWrapper, all instantiations of the generic type match it.
+type registeredWrapper struct {
+	Pkg    string
+	Name   string
+	Getter string
+}
+
+// pendingCall is the call checked in main packages.
+type pendingCall struct {
+	// Pos is the position of the call and Pkg is the path of its package.
+	Pos    string
+	Pkg    string
+	Source *typeRef
+	Dest   *typeRef
+	// Message is the message of the diagnostic with types qualified relative to the package of the call.
+	Message string
+}
+
+// mapCall is the mapping call with static source and destination types.
+type mapCall struct {
+	call   *ast.CallExpr
+	source types.Type
+	dest   types.Type
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	own, calls := collect(pass.TypesInfo, pass.Files)
+	if len(own.Routes) > 0 || len(own.Wrappers) > 0 {
+		pass.ExportPackageFact(own)
+	}
+	isMain := pass.Pkg.Name() == "main"
+	var pending []pendingCall
+	for _, f := range pass.AllPackageFacts() {
+		if fact, ok := f.Fact.(*pendingFact); ok && isMain {
+			pending = append(pending, fact.Calls...)
+		}
+	}
+	if len(calls) == 0 && len(pending) == 0 {
+		return nil, nil
+	}
+
+	registry := newRegistry(pass.Pkg)
+	registry.add(own)
+	for _, f := range pass.AllPackageFacts() {
+		if fact, ok := f.Fact.(*routesFact); ok {
+			registry.add(fact)
+		}
+	}
+	qualifier := types.RelativeTo(pass.Pkg)
+	var unresolved []pendingCall
+	for _, c := range calls {
+		if c.source == nil || c.dest == nil {
+			continue
+		}
+		if registry.hasRoute(c.source, c.dest) {
+			continue
+		}
+		message := "no route registered for type " + types.TypeString(c.source, qualifier) +
+			" to type " + types.TypeString(c.dest, qualifier)
+		if isMain || !reportInMain {
+			pass.Report(analysis.Diagnostic{Pos: c.call.Pos(), Message: message})
+			continue
+		}
+		unresolved = append(unresolved, pendingCall{
+			Pos:     pass.Fset.Position(c.call.Pos()).String(),
+			Pkg:     pass.Pkg.Path(),
+			Source:  newTypeRef(c.source),
+			Dest:    newTypeRef(c.dest),
+			Message: message,
+		})
+	}
+	if len(unresolved) > 0 {
+		pass.ExportPackageFact(&pendingFact{Calls: unresolved})
+	}
+	for _, c := range pending {
+		if !registry.hasPendingRoute(c) {
+			pass.Reportf(importPos(pass, c.Pkg), "%s: %s", c.Pos, c.Message)
+		}
+	}
+	return nil, nil
+}
+
+// importPos returns the position of the import of the package of the call or the package importing it,
+// calls of other packages are reported at imports leading to them.
+func importPos(pass *analysis.Pass, path string) token.Pos {
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			imported, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			for _, pkg := range pass.Pkg.Imports() {
+				if pkg.Path() == imported && findPackage(pkg, path, map[*types.Package]bool{}) != nil {
+					return spec.Pos()
+				}
+			}
+		}
+	}
+	return pass.Files[0].Name.Pos()
+}
+
+// collect returns routes and wrapper types registered in files and mapping calls with static types.
+func collect(info *types.Info, files []*ast.File) (*routesFact, []mapCall) {
+	var routes []registeredRoute
+	var wrappers []registeredWrapper
+	var calls []mapCall
+	// guarded are bodies of if statements checking HasRoute, calls in them are mapped only when the route exists
+	var guarded []*ast.BlockStmt
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if stmt, ok := n.(*ast.IfStmt); ok && isHasRouteCall(info, stmt.Cond) {
+				guarded = append(guarded, stmt.Body)
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, ok := typeutil.Callee(info, call).(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != gomapperPath {
+				return true
+			}
+			typeArgs := getTypeArgs(info, call.Fun)
+			switch fn.Name() {
+			case "AddRoute", "AddConverter", "AddPolymorphicRoute", "AutoRoute", "AutoRouteBidirectional":
+				if len(typeArgs) != 2 {
+					return true
+				}
+				routes = append(routes, newRegisteredRoute(typeArgs[0], typeArgs[1]))
+				if fn.Name() == "AutoRouteBidirectional" || hasReverseMap(info, call) {
+					routes = append(routes, newRegisteredRoute(typeArgs[1], typeArgs[0]))
+				}
+			case "AutoMapRoute":
+				if len(typeArgs) != 1 {
+					return true
+				}
+				mapType := types.NewMap(types.Typ[types.String], types.Universe.Lookup("any").Type())
+				routes = append(routes, newRegisteredRoute(typeArgs[0], mapType), newRegisteredRoute(mapType, typeArgs[0]))
+			case "AutoValuesRoute":
+				if len(typeArgs) != 1 {
+					return true
+				}
+				valuesType := types.NewMap(types.Typ[types.String], types.NewSlice(types.Typ[types.String]))
+				routes = append(routes, newRegisteredRoute(valuesType, typeArgs[0]),
+					registeredRoute{Source: "net/url.Values", Dest: typeKey(typeArgs[0])})
+			case "AddWrapper":
+				if len(typeArgs) != 1 || len(call.Args) != 2 {
+					return true
+				}
+				if w, ok := newRegisteredWrapper(info, typeArgs[0], call.Args[0]); ok {
+					wrappers = append(wrappers, w)
+				}
+			case "MapTo":
+				if len(typeArgs) != 1 || len(call.Args) != 1 {
+					return true
+				}
+				calls = append(calls, mapCall{call: call, source: info.TypeOf(call.Args[0]), dest: typeArgs[0]})
+			case "MapAs", "MapSeq", "MapChan":
+				// sources and destinations, or elements of streams, have types of type arguments
+				if len(typeArgs) != 2 {
+					return true
+				}
+				calls = append(calls, mapCall{call: call, source: typeArgs[0], dest: typeArgs[1]})
+			case "Map", "MapParallel":
+				if len(call.Args) < 2 {
+					return true
+				}
+				destPtr, ok := info.TypeOf(call.Args[1]).(*types.Pointer)
+				if !ok {
+					return true
+				}
+				calls = append(calls, mapCall{call: call, source: info.TypeOf(call.Args[0]), dest: destPtr.Elem()})
+			}
+			return true
+		})
+	}
+	checked := calls[:0]
+	for _, c := range calls {
+		if !isGuarded(guarded, c.call) {
+			checked = append(checked, c)
+		}
+	}
+	return &routesFact{Routes: routes, Wrappers: wrappers}, checked
+}
+
+// isHasRouteCall reports whether the expression is the HasRoute call.
+func isHasRouteCall(info *types.Info, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == gomapperPath && fn.Name() == "HasRoute"
+}
+
+func isGuarded(guarded []*ast.BlockStmt, call *ast.CallExpr) bool {
+	for _, body := range guarded {
+		if body.Pos() <= call.Pos() && call.End() <= body.End() {
+			return true
+		}
+	}
+	return false
+}
+
+func newRegisteredRoute(source, dest types.Type) registeredRoute {
+	r := registeredRoute{Source: typeKey(source), Dest: typeKey(dest)}
+	if named, ok := source.(*types.Named); ok && types.IsInterface(named) && named.Obj().Pkg() != nil {
+		r.InterfacePkg = named.Obj().Pkg().Path()
+		r.InterfaceName = named.Obj().Name()
+	}
+	return r
+}
+
+// newRegisteredWrapper returns the wrapper type registered with the getter passed as the constant.
+func newRegisteredWrapper(info *types.Info, typ types.Type, getterArg ast.Expr) (registeredWrapper, bool) {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return registeredWrapper{}, false
+	}
+	getter := info.Types[getterArg].Value
+	if getter == nil || getter.Kind() != constant.String {
+		return registeredWrapper{}, false
+	}
+	obj := named.Origin().Obj()
+	return registeredWrapper{Pkg: obj.Pkg().Path(), Name: obj.Name(), Getter: constant.StringVal(getter)}, true
+}
+
+// getTypeArgs returns type arguments of the generic function call, including inferred ones.
+func getTypeArgs(info *types.Info, fun ast.Expr) []types.Type {
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil
+	}
+	instance, ok := info.Instances[ident]
+	if !ok {
+		return nil
+	}
+	typeArgs := make([]types.Type, 0, instance.TypeArgs.Len())
+	for i := 0; i < instance.TypeArgs.Len(); i++ {
+		typeArgs = append(typeArgs, instance.TypeArgs.At(i))
+	}
+	return typeArgs
+}
+
+// hasReverseMap reports whether the ReverseMap option is passed to the call.
+func hasReverseMap(info *types.Info, call *ast.CallExpr) bool {
+	for _, arg := range call.Args {
+		argCall, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		fn, ok := typeutil.Callee(info, argCall).(*types.Func)
+		if ok && fn.Pkg() != nil && fn.Pkg().Path() == gomapperPath && fn.Name() == "ReverseMap" {
+			return true
+		}
+	}
+	return false
+}
+
+func typeKey(t types.Type) string {
+	return types.TypeString(unalias(t), nil)
+}
+
+// unalias replaces aliases in the type and its element types, so any and interface{} have the same key.
+func unalias(t types.Type) types.Type {
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return types.NewPointer(unalias(t.Elem()))
+	case *types.Slice:
+		return types.NewSlice(unalias(t.Elem()))
+	case *types.Array:
+		return types.NewArray(unalias(t.Elem()), t.Len())
+	case *types.Map:
+		return types.NewMap(unalias(t.Key()), unalias(t.Elem()))
+	default:
+		return t
+	}
+}
+
+// registry resolves routes like findRoute of gomapper.
+type registry struct {
+	pkg    *types.Package
+	routes map[string]map[string]bool
+	// interfaces are destinations of routes from interfaces
+	interfaces map[*types.Interface][]string
+	// unresolved are destinations of routes from interfaces, which types are not found in imported packages
+	unresolved map[string]bool
+	wrappers   []registeredWrapper
+}
+
+func newRegistry(pkg *types.Package) *registry {
+	return &registry{
+		pkg:        pkg,
+		routes:     map[string]map[string]bool{},
+		interfaces: map[*types.Interface][]string{},
+		unresolved: map[string]bool{},
+	}
+}
+
+func (r *registry) add(fact *routesFact) {
+	for _, route := range fact.Routes {
+		if r.routes[route.Source] == nil {
+			r.routes[route.Source] = map[string]bool{}
+		}
+		r.routes[route.Source][route.Dest] = true
+		if route.InterfaceName == "" {
+			continue
+		}
+		iface := r.lookupInterface(route.InterfacePkg, route.InterfaceName)
+		if iface == nil {
+			r.unresolved[route.Dest] = true
+			continue
+		}
+		r.interfaces[iface] = append(r.interfaces[iface], route.Dest)
+	}
+	r.wrappers = append(r.wrappers, fact.Wrappers...)
+}
+
+func (r *registry) lookupInterface(pkgPath, name string) *types.Interface {
+	obj, ok := r.lookup(pkgPath, name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	iface, _ := obj.Type().Underlying().(*types.Interface)
+	return iface
+}
+
+// lookup returns the object declared in the package imported by the package of the registry.
+func (r *registry) lookup(pkgPath, name string) types.Object {
+	pkg := findPackage(r.pkg, pkgPath, map[*types.Package]bool{})
+	if pkg == nil {
+		return nil
+	}
+	return pkg.Scope().Lookup(name)
+}
+
+func findPackage(pkg *types.Package, path string, visited map[*types.Package]bool) *types.Package {
+	if pkg.Path() == path {
+		return pkg
+	}
+	visited[pkg] = true
+	for _, imported := range pkg.Imports() {
+		if visited[imported] {
+			continue
+		}
+		if found := findPackage(imported, path, visited); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func (r *registry) has(source, dest types.Type) bool {
+	return r.routes[typeKey(source)][typeKey(dest)]
+}
+
+// hasRoute reports whether the route from the source type to the destination type is registered or built-in.
+func (r *registry) hasRoute(source, dest types.Type) bool {
+	deref := source
+	if ptr, ok := source.(*types.Pointer); ok {
+		deref = ptr.Elem()
+	}
+	for _, t := range []types.Type{source, deref, types.NewPointer(deref)} {
+		// routes to pointers write through destinations of pointed types
+		if r.has(t, dest) || r.has(t, types.NewPointer(dest)) {
+			return true
+		}
+	}
+	if types.IsInterface(deref) {
+		// the route is resolved by the dynamic type of the source
+		return true
+	}
+	if r.hasWrapperRoute(source, dest) || r.hasSliceRoute(deref, dest) {
+		return true
+	}
+	destKey := typeKey(dest)
+	if r.unresolved[destKey] {
+		return true
+	}
+	for iface, dests := range r.interfaces {
+		if !types.Implements(deref, iface) && !types.Implements(types.NewPointer(deref), iface) {
+			continue
+		}
+		for _, d := range dests {
+			if d == destKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSliceRoute reports whether slices are mapped element by element with the route between slice elements,
+// elements of both slices can be values or pointers.
+func (r *registry) hasSliceRoute(source, dest types.Type) bool {
+	sourceSlice, ok := source.(*types.Slice)
+	if !ok {
+		return false
+	}
+	destSlice, ok := dest.(*types.Slice)
+	if !ok {
+		return false
+	}
+	destElem := destSlice.Elem()
+	if ptr, ok := destElem.(*types.Pointer); ok {
+		destElem = ptr.Elem()
+	}
+	return r.hasRoute(sourceSlice.Elem(), destElem)
+}
+
+// hasWrapperRoute reports whether the built-in route between the wrapper type and its value or the pointer to it,
+// or between wrapper types with convertible values exists.
+func (r *registry) hasWrapperRoute(source, dest types.Type) bool {
+	if types.Identical(source, dest) {
+		return false
+	}
+	if ptr, ok := source.(*types.Pointer); ok {
+		if _, ok := r.wrapperElem(ptr.Elem()); ok {
+			// the dereferenced source is mapped
+			return r.hasWrapperRoute(ptr.Elem(), dest)
+		}
+	}
+	if sourceElem, ok := r.wrapperElem(source); ok {
+		if destElem, ok := r.wrapperElem(dest); ok {
+			return r.converts(sourceElem, destElem)
+		}
+		if ptr, ok := dest.(*types.Pointer); ok && !r.converts(sourceElem, dest) {
+			return r.converts(sourceElem, ptr.Elem())
+		}
+		return r.converts(sourceElem, dest)
+	}
+	if destElem, ok := r.wrapperElem(dest); ok {
+		if ptr, ok := source.(*types.Pointer); ok && !r.converts(source, destElem) {
+			return r.converts(ptr.Elem(), destElem)
+		}
+		return r.converts(source, destElem)
+	}
+	return false
+}
+
+// converts reports whether the wrapped value is assigned or mapped to the type.
+func (r *registry) converts(source, dest types.Type) bool {
+	return types.AssignableTo(source, dest) || r.hasRoute(source, dest)
+}
+
+// wrapperElem returns the type of the value wrapped by sql.Null[T], sql.NullX or the registered wrapper type.
+func (r *registry) wrapperElem(t types.Type) (types.Type, bool) {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil, false
+	}
+	obj := named.Origin().Obj()
+	if obj.Pkg().Path() == "database/sql" && strings.HasPrefix(obj.Name(), "Null") {
+		st, ok := named.Underlying().(*types.Struct)
+		if !ok || st.NumFields() != 2 || st.Field(1).Name() != "Valid" ||
+			!types.Identical(st.Field(1).Type(), types.Typ[types.Bool]) {
+			return nil, false
+		}
+		return st.Field(0).Type(), true
+	}
+	for _, w := range r.wrappers {
+		if obj.Pkg().Path() != w.Pkg || obj.Name() != w.Name {
+			continue
+		}
+		getter, _, _ := types.LookupFieldOrMethod(named, false, obj.Pkg(), w.Getter)
+		fn, ok := getter.(*types.Func)
+		if !ok {
+			continue
+		}
+		if sig := fn.Type().(*types.Signature); sig.Params().Len() == 0 && sig.Results().Len() == 2 {
+			return sig.Results().At(0).Type(), true
+		}
+	}
+	return nil, false
+}
+
+// hasPendingRoute reports whether the route of the call of another package is registered in the program,
+// types not found in imported packages are compared by their keys.
+func (r *registry) hasPendingRoute(c pendingCall) bool {
+	source, dest := c.Source.resolve(r), c.Dest.resolve(r)
+	if source != nil && dest != nil {
+		return r.hasRoute(source, dest)
+	}
+	if r.routes[c.Source.Key][c.Dest.Key] {
+		return true
+	}
+	return c.Source.Elem != nil && c.Source.Kind == "pointer" && r.routes[c.Source.Elem.Key][c.Dest.Key]
+}
+
+// typeRef identifies the type of the pending call, so it's resolved in packages importing the package of the call.
+type typeRef struct {
+	Key string
+	// Kind is "named", "basic", "pointer", "slice", "map" or "interface", other types are not resolved
+	Kind     string
+	Pkg      string
+	Name     string
+	TypeArgs []*typeRef
+	Elem     *typeRef
+	MapKey   *typeRef
+}
+
+func newTypeRef(t types.Type) *typeRef {
+	t = types.Unalias(t)
+	ref := &typeRef{Key: typeKey(t)}
+	switch t := t.(type) {
+	case *types.Named:
+		obj := t.Origin().Obj()
+		if obj.Pkg() == nil {
+			// error is declared in the universe scope
+			ref.Kind, ref.Name = "basic", obj.Name()
+			return ref
+		}
+		ref.Kind, ref.Pkg, ref.Name = "named", obj.Pkg().Path(), obj.Name()
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			ref.TypeArgs = append(ref.TypeArgs, newTypeRef(t.TypeArgs().At(i)))
+		}
+	case *types.Basic:
+		ref.Kind, ref.Name = "basic", t.Name()
+	case *types.Pointer:
+		ref.Kind, ref.Elem = "pointer", newTypeRef(t.Elem())
+	case *types.Slice:
+		ref.Kind, ref.Elem = "slice", newTypeRef(t.Elem())
+	case *types.Map:
+		ref.Kind, ref.MapKey, ref.Elem = "map", newTypeRef(t.Key()), newTypeRef(t.Elem())
+	case *types.Interface:
+		if t.Empty() {
+			ref.Kind = "interface"
+		}
+	}
+	return ref
+}
+
+// resolve returns the type found in packages imported by the package of the registry or nil.
+func (ref *typeRef) resolve(r *registry) types.Type {
+	switch ref.Kind {
+	case "named":
+		obj, ok := r.lookup(ref.Pkg, ref.Name).(*types.TypeName)
+		if !ok {
+			return nil
+		}
+		if len(ref.TypeArgs) == 0 {
+			return obj.Type()
+		}
+		typeArgs := make([]types.Type, 0, len(ref.TypeArgs))
+		for _, arg := range ref.TypeArgs {
+			t := arg.resolve(r)
+			if t == nil {
+				return nil
+			}
+			typeArgs = append(typeArgs, t)
+		}
+		t, err := types.Instantiate(nil, obj.Type(), typeArgs, false)
+		if err != nil {
+			return nil
+		}
+		return t
+	case "basic":
+		obj, ok := types.Universe.Lookup(ref.Name).(*types.TypeName)
+		if !ok {
+			return nil
+		}
+		return obj.Type()
+	case "pointer", "slice":
+		elem := ref.Elem.resolve(r)
+		if elem == nil {
+			return nil
+		}
+		if ref.Kind == "pointer" {
+			return types.NewPointer(elem)
+		}
+		return types.NewSlice(elem)
+	case "map":
+		key, elem := ref.MapKey.resolve(r), ref.Elem.resolve(r)
+		if key == nil || elem == nil {
+			return nil
+		}
+		return types.NewMap(key, elem)
+	case "interface":
+		return types.NewInterfaceType(nil, nil)
+	}
+	return nil
+}
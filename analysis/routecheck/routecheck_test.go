@@ -0,0 +1,23 @@
+package routecheck
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	// calls are reported in the package without the main package
+	if err := Analyzer.Flags.Set("main", "false"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = Analyzer.Flags.Set("main", "true") }()
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestAnalyzerModule(t *testing.T) {
+	// routes registered in the main package are found for calls in the package it imports,
+	// calls without routes are reported at the import of the package
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "mod"), Analyzer, "./cmd/app")
+}
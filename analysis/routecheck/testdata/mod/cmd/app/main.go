@@ -0,0 +1,11 @@
+package main // want package:"routes"
+
+import (
+	"example.com/mod/models" // want `models.go:\d+:\d+: no route registered for type Order to type OrderDTO`
+	"github.com/insei/gomapper"
+)
+
+func main() {
+	_ = gomapper.AutoRoute[models.User, models.UserDTO]()
+	_, _ = models.Users(nil)
+}
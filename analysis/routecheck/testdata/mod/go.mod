@@ -0,0 +1,7 @@
+module example.com/mod
+
+go 1.22.0
+
+require github.com/insei/gomapper v0.0.0
+
+replace github.com/insei/gomapper => ./gomapper
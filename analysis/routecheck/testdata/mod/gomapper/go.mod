@@ -0,0 +1,3 @@
+module github.com/insei/gomapper
+
+go 1.22.0
@@ -0,0 +1,76 @@
+// Package gomapper is the stub of gomapper API used by routecheck tests.
+package gomapper
+
+import "context"
+
+type Option interface{}
+
+type RouteOption interface{}
+
+type SubRoute struct{}
+
+func AddRoute[TSource, TDest any](mapFunc func(source TSource, dest *TDest) error, opts ...RouteOption) error {
+	return nil
+}
+
+func AddConverter[TSource, TDest any](convert func(source TSource) (TDest, error), opts ...RouteOption) error {
+	return nil
+}
+
+func AddPolymorphicRoute[TSourceIface, TDestIface any](subRoutes []SubRoute, opts ...RouteOption) error {
+	return nil
+}
+
+func AutoRoute[TSource, TDest any](opts ...Option) error {
+	return nil
+}
+
+func AutoRouteBidirectional[TSource, TDest any](opts ...Option) error {
+	return nil
+}
+
+func AutoMapRoute[TStruct any](opts ...Option) error {
+	return nil
+}
+
+func AutoValuesRoute[TStruct any](opts ...Option) error {
+	return nil
+}
+
+func AddWrapper[TWrapper any](getter, setter string) error {
+	return nil
+}
+
+func HasRoute[TSource, TDest any]() bool {
+	return false
+}
+
+func ReverseMap() Option {
+	return nil
+}
+
+func Map(source interface{}, dest interface{}) error {
+	return nil
+}
+
+func MapTo[TDest interface{}](source interface{}) (TDest, error) {
+	return *new(TDest), nil
+}
+
+type MapOption interface{}
+
+func MapParallel(source interface{}, dest interface{}, opts ...MapOption) error {
+	return nil
+}
+
+func MapAs[TSource, TDest any](source TSource) (TDest, error) {
+	return *new(TDest), nil
+}
+
+func MapSeq[TSource, TDest any](seq func(yield func(TSource) bool)) func(yield func(TDest, error) bool) {
+	return nil
+}
+
+func MapChan[TSource, TDest any](ctx context.Context, in <-chan TSource) (<-chan TDest, <-chan error) {
+	return nil, nil
+}
@@ -0,0 +1,23 @@
+package models
+
+import "github.com/insei/gomapper"
+
+type User struct {
+	Name string
+}
+
+type UserDTO struct {
+	Name string
+}
+
+type Order struct{}
+
+type OrderDTO struct{}
+
+func Users(users []User) ([]UserDTO, error) {
+	return gomapper.MapTo[[]UserDTO](users)
+}
+
+func Orders(order Order) (OrderDTO, error) {
+	return gomapper.MapTo[OrderDTO](order)
+}
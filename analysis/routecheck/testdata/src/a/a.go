@@ -0,0 +1,105 @@
+package a // want package:"routes"
+
+import (
+	"context"
+	"database/sql"
+	"models"
+	"net/url"
+
+	"github.com/insei/gomapper"
+)
+
+type User struct {
+	Name string
+}
+
+func (u User) GetName() string {
+	return u.Name
+}
+
+type UserDTO struct {
+	Name string
+}
+
+type Entity struct{}
+
+type EntityDTO struct{}
+
+type Record struct{}
+
+type Query struct{}
+
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+func (o *Optional[T]) Set(value T) {
+	o.value, o.set = value, true
+}
+
+type Event interface {
+	ID() string
+}
+
+func register() {
+	_ = gomapper.AutoRoute[Entity, EntityDTO](gomapper.ReverseMap())
+	_ = gomapper.AutoMapRoute[Record]()
+	_ = gomapper.AutoRoute[Record, *Query]()
+	_ = gomapper.AutoValuesRoute[Query]()
+	_ = gomapper.AddConverter(func(source int) (string, error) {
+		return "", nil
+	})
+	_ = gomapper.AddWrapper[Optional[any]]("Get", "Set")
+}
+
+func mapping(event Event) {
+	_, _ = gomapper.MapTo[models.AddressDTO](models.Address{})
+	_, _ = gomapper.MapTo[models.AddressDTO](&models.Address{})
+	_, _ = gomapper.MapTo[[]*models.AddressDTO]([]models.Address{})
+	_, _ = gomapper.MapTo[EntityDTO](Entity{})
+	_ = gomapper.Map(Record{}, &Query{})
+	_, _ = gomapper.MapTo[Entity](EntityDTO{})
+	_, _ = gomapper.MapTo[string](1)
+	_, _ = gomapper.MapTo[models.NamedDTO](User{})
+	_, _ = gomapper.MapTo[UserDTO](event)
+	_ = gomapper.Map(models.Address{}, &models.AddressDTO{})
+	_, _ = gomapper.MapTo[map[string]any](Record{})
+	_, _ = gomapper.MapTo[map[string]interface{}](&Record{})
+	_, _ = gomapper.MapTo[[]Record]([]map[string]any{})
+	_, _ = gomapper.MapTo[Query](url.Values{})
+	_, _ = gomapper.MapTo[Query](map[string][]string{})
+	_, _ = gomapper.MapTo[[]models.NamedDTO]([]User{})
+	_, _ = gomapper.MapTo[*string](sql.NullString{})
+	_, _ = gomapper.MapTo[sql.NullString](new(string))
+	_, _ = gomapper.MapTo[string](&sql.Null[int]{})
+	_, _ = gomapper.MapTo[Optional[int64]](sql.NullInt64{})
+	_, _ = gomapper.MapTo[string](Optional[int]{})
+	_, _ = gomapper.MapTo[[]EntityDTO]([]Optional[Entity]{})
+
+	_, _ = gomapper.MapTo[UserDTO](User{})           // want `no route registered for type User to type UserDTO`
+	_, _ = gomapper.MapTo[[]UserDTO]([]User{})       // want `no route registered for type \[\]User to type \[\]UserDTO`
+	_, _ = gomapper.MapTo[int]("1")                  // want `no route registered for type string to type int`
+	_, _ = gomapper.MapTo[map[string]any](User{})    // want `no route registered for type User to type map\[string\]any`
+	_, _ = gomapper.MapTo[Record](url.Values{})      // want `no route registered for type net/url.Values to type Record`
+	_, _ = gomapper.MapTo[int](sql.NullString{})     // want `no route registered for type database/sql.NullString to type int`
+	_, _ = gomapper.MapTo[UserDTO](Optional[User]{}) // want `no route registered for type Optional\[User\] to type UserDTO`
+	dest := UserDTO{}
+	if gomapper.HasRoute[User, UserDTO]() {
+		_ = gomapper.Map(User{}, &dest)
+	}
+	_ = gomapper.Map(&User{}, &dest) // want `no route registered for type \*User to type UserDTO`
+
+	_, _ = gomapper.MapAs[Entity, EntityDTO](Entity{})
+	_ = gomapper.MapParallel([]models.Address{}, &[]models.AddressDTO{})
+	_ = gomapper.MapSeq[*models.Address, models.AddressDTO](nil)
+	_, _ = gomapper.MapChan[Entity, EntityDTO](context.Background(), nil)
+	_, _ = gomapper.MapAs[User, UserDTO](User{})                      // want `no route registered for type User to type UserDTO`
+	_ = gomapper.MapParallel([]User{}, &[]UserDTO{})                  // want `no route registered for type \[\]User to type \[\]UserDTO`
+	_ = gomapper.MapSeq[User, UserDTO](nil)                           // want `no route registered for type User to type UserDTO`
+	_, _ = gomapper.MapChan[User, UserDTO](context.Background(), nil) // want `no route registered for type User to type UserDTO`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "github.com/insei/gomapper"
+
+type Address struct {
+	City string
+}
+
+type AddressDTO struct {
+	City string
+}
+
+type Named interface {
+	GetName() string
+}
+
+type NamedDTO struct {
+	Name string
+}
+
+func init() {
+	_ = gomapper.AutoRoute[Address, AddressDTO]()
+	_ = gomapper.AddRoute(func(source Named, dest *NamedDTO) error {
+		dest.Name = source.GetName()
+		return nil
+	})
+}
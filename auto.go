@@ -1,51 +1,132 @@
 package gomapper
 
 import (
+	"fmt"
 	"reflect"
-	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/insei/fmap/v3"
 )
 
-var (
-	manualFieldRoutes = map[reflect.Type]map[string]string{}
-)
+type fieldPair struct {
+	source fmap.Field
+	dest   fmap.Field
+	match  FieldMatch
+	// conditions are indexes of options conditions which should be true to map the field
+	conditions []int
+}
 
+// autoRoute is the auto route configuration resolved on registration.
+type autoRoute struct {
+	destStorage   fmap.Storage
+	opts          *options
+	fields        []fieldPair
+	defaults      []fieldDefault
+	skippedSource []string
+	skippedDest   []string
+	// notInverted are options of the forward route which aren't applied to the reverse route.
+	notInverted []string
+}
+
+// AutoRoute registers the route which maps fields with matching names and types.
+// If the destination has no field with the source field path, the flattened path is used,
+// i.e. Address.City source field is mapped to AddressCity destination field.
+// Routes between pointers to structs, i.e. AutoRoute[*pb.User, User], read and write structs through pointers,
+// so structs which must not be copied, like protobuf messages, are mapped in place.
 func AutoRoute[TSource, TDest any | []any](opts ...Option) error {
+	return autoRoutes[TSource, TDest](getCallSite(1), opts)
+}
+
+// AutoRouteBidirectional registers auto routes in both directions, see ReverseMap.
+func AutoRouteBidirectional[TSource, TDest any | []any](opts ...Option) error {
+	return autoRoutes[TSource, TDest](getCallSite(1), append(opts, ReverseMap()))
+}
+
+func autoRoutes[TSource, TDest any | []any](callSite string, opts []Option) error {
 	s := new(TSource)
 	d := new(TDest)
-	sourceStorage, _ := fmap.GetFrom(s)
-	destStorage, _ := fmap.GetFrom(d)
-	sourceType := reflect.TypeOf(s)
+	sourceStruct, destStruct := newStructPtr[TSource](), newStructPtr[TDest]()
+	sourceStorage, err := getStorage(sourceStruct)
+	if err != nil {
+		return fmt.Errorf("%w, route: %s -> %s", err, getTypeName(*s), getTypeName(*d))
+	}
+	destStorage, err := getStorage(destStruct)
+	if err != nil {
+		return fmt.Errorf("%w, route: %s -> %s", err, getTypeName(*s), getTypeName(*d))
+	}
 
 	opt := &options{}
 	for _, o := range opts {
 		o.apply(opt)
 	}
+	if err = opt.validate(reflect.TypeOf(sourceStruct).Elem(), reflect.TypeOf(destStruct).Elem()); err != nil {
+		return fmt.Errorf("%w, route: %s -> %s", err, getTypeName(*s), getTypeName(*d))
+	}
 
-	mapFunc := func(source TSource, dest *TDest) error {
-		for _, sourcePath := range sourceStorage.GetAllPaths() {
-			destFld, ok := destStorage.Find(getDestFieldName(sourceType, sourcePath))
-			if !ok {
-				continue
-			}
+	if opt.Reverse {
+		// the reverse route is checked before the registration of the forward route to avoid partial registration
+		if err = checkRoute(reflect.TypeOf(d).Elem(), reflect.TypeOf(s), opt.Replace); err != nil {
+			return err
+		}
+	}
 
-			srcFld := sourceStorage.MustFind(sourcePath)
-			if slices.Contains(opt.Excluded, srcFld) {
-				continue
-			}
+	auto, err := newAutoRoute(sourceStorage, destStorage, opt)
+	if err != nil {
+		return fmt.Errorf("%w, route: %s -> %s", err, getTypeName(*s), getTypeName(*d))
+	}
+	if err = addAutoRoute[TSource, TDest](auto, callSite); err != nil {
+		return err
+	}
+	if !opt.Reverse {
+		return nil
+	}
 
-			if destFld.GetType() != srcFld.GetType() {
-				_ = Map(srcFld.Get(source), destFld.GetPtr(dest))
-				continue
-			}
+	reverse, err := auto.reverse(sourceStorage)
+	if err != nil {
+		return fmt.Errorf("%w, route: %s -> %s", err, getTypeName(*d), getTypeName(*s))
+	}
+	return addAutoRoute[TDest, TSource](reverse, callSite)
+}
 
-			if err := setFieldRecursive(srcFld, destFld, source, dest); err != nil {
-				return err
+// newStructPtr returns the pointer to the new struct of T or of the struct pointed by T, so auto routes between
+// pointers to structs read and write structs through pointers.
+func newStructPtr[T any | []any]() any {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() == reflect.Ptr {
+		return reflect.New(typ.Elem()).Interface()
+	}
+	return new(T)
+}
+
+func addAutoRoute[TSource, TDest any | []any](auto *autoRoute, callSite string) error {
+	isSourcePtr := reflect.TypeOf((*TSource)(nil)).Elem().Kind() == reflect.Ptr
+	isDestPtr := reflect.TypeOf((*TDest)(nil)).Elem().Kind() == reflect.Ptr
+	mapFunc := func(s *mapState, source TSource, dest *TDest) error {
+		if auto.opts.Depth != nil {
+			defer s.limitDepth(*auto.opts.Depth)()
+		}
+		if err := s.enter(); err != nil {
+			return err
+		}
+		defer s.exit()
+		// fields are read through the pointer, fmap can't read fields of structs stored directly in interfaces
+		var sourcePtr, destPtr any = &source, dest
+		if isSourcePtr {
+			sourcePtr = source
+		}
+		if isDestPtr {
+			destValue := reflect.ValueOf(dest).Elem()
+			if destValue.IsNil() {
+				destValue.Set(reflect.New(destValue.Type().Elem()))
 			}
+			destPtr = destValue.Interface()
+		}
+		if err := auto.mapFields(s, sourcePtr, destPtr); err != nil {
+			return err
 		}
 
-		for _, o := range opt.Fns {
+		for _, o := range auto.opts.Fns {
 			fn, ok := o.(func(TSource, *TDest))
 			if !ok {
 				continue
@@ -56,57 +137,403 @@ func AutoRoute[TSource, TDest any | []any](opts ...Option) error {
 		return nil
 	}
 
-	return AddRoute[TSource, TDest](mapFunc)
+	return addRoute[TSource, TDest](mapFunc, &route{
+		info: RouteInfo{
+			Kind:                RouteKindAuto,
+			CallSite:            callSite,
+			Fields:              auto.fieldsInfo(),
+			SkippedSourceFields: auto.skippedSource,
+			SkippedDestFields:   auto.skippedDest,
+			NotInverted:         auto.notInverted,
+		},
+		auto: auto,
+	}, auto.opts.Replace)
 }
 
-func setFieldRecursive(sourceFld, destFld fmap.Field, source, dest any) error {
-	if r, ok := getRouteIfExists(sourceFld, destFld); ok {
-		return r(sourceFld.Get(source), destFld.GetPtr(dest))
+func newAutoRoute(sourceStorage, destStorage fmap.Storage, opt *options) (*autoRoute, error) {
+	tagDefaults, err := getTagDefaults(destStorage, opt)
+	if err != nil {
+		return nil, err
 	}
+	return &autoRoute{
+		destStorage:   destStorage,
+		opts:          opt,
+		fields:        getFieldPairs(sourceStorage, destStorage, opt),
+		defaults:      append(opt.Defaults, tagDefaults...),
+		skippedSource: getSelectedPaths(sourceStorage, opt.Excluded),
+		skippedDest:   getSelectedPaths(destStorage, opt.DestExcluded),
+	}, nil
+}
 
-	if sourceFld.GetType().Kind() != reflect.Struct {
-		sourceVal := sourceFld.Get(source)
-		if sourceVal != nil {
-			destFld.Set(dest, sourceVal)
+// reverse returns the route with inverted field pairs, so renames, flattening and skips are inverted too.
+func (r *autoRoute) reverse(sourceStorage fmap.Storage) (*autoRoute, error) {
+	opt := &options{Replace: r.opts.Replace, Depth: r.opts.Depth}
+	tagDefaults, err := getTagDefaults(sourceStorage, opt)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]fieldPair, 0, len(r.fields))
+	for _, f := range r.fields {
+		match := f.match
+		switch f.match {
+		case FieldMatchFlatten:
+			match = FieldMatchUnflatten
+		case FieldMatchUnflatten:
+			match = FieldMatchFlatten
+		}
+		fields = append(fields, fieldPair{source: f.dest, dest: f.source, match: match})
+	}
+	return &autoRoute{
+		destStorage:   sourceStorage,
+		opts:          opt,
+		fields:        fields,
+		defaults:      tagDefaults,
+		skippedSource: r.skippedDest,
+		skippedDest:   r.skippedSource,
+		notInverted:   r.notInvertibleOptions(),
+	}, nil
+}
+
+func (r *autoRoute) fieldsInfo() []RouteField {
+	fields := make([]RouteField, 0, len(r.fields))
+	for _, f := range r.fields {
+		fields = append(fields, RouteField{
+			SourcePath:  f.source.GetStructPath(),
+			DestPath:    f.dest.GetStructPath(),
+			Match:       f.match,
+			Conditional: len(f.conditions) > 0,
+		})
+	}
+	return fields
+}
+
+// notInvertibleOptions describes options which are not applied to the reverse route.
+func (r *autoRoute) notInvertibleOptions() []string {
+	var notInvertible []string
+	for range r.opts.Fns {
+		notInvertible = append(notInvertible, "WithFunc")
+	}
+	for _, c := range r.opts.Conditions {
+		notInvertible = append(notInvertible, fmt.Sprintf("condition on field %s", c.field.path))
+	}
+	for _, res := range r.opts.Resolvers {
+		notInvertible = append(notInvertible, fmt.Sprintf("resolver of field %s", res.field.path))
+	}
+	for _, d := range r.opts.Defaults {
+		notInvertible = append(notInvertible, fmt.Sprintf("default of field %s", d.field.path))
+	}
+	return notInvertible
+}
+
+func (r *autoRoute) mapFields(s *mapState, source any, dest any) error {
+	conditions := make([]bool, len(r.opts.Conditions))
+	for i, c := range r.opts.Conditions {
+		conditions[i] = c.check(source)
+	}
+	for _, f := range r.fields {
+		if !f.isAllowed(conditions) {
+			continue
+		}
+		if err := setField(s, f.source, f.dest, source, dest); err != nil {
+			return err
 		}
-		return nil
 	}
 
-	sourceStructField := sourceFld.GetPtr(source)
-	sourceStorage, _ := fmap.GetFrom(sourceStructField)
+	for _, res := range r.opts.Resolvers {
+		if err := res.resolve(source, dest); err != nil {
+			return fmt.Errorf("resolve field %s: %w", res.field.path, err)
+		}
+	}
+
+	for _, d := range r.defaults {
+		d.set(dest)
+	}
+	return nil
+}
 
-	destStructField := destFld.GetPtr(dest)
-	destStorage, _ := fmap.GetFrom(destStructField)
+// isAllowed reports whether all the field conditions are met, conditions contains results of options conditions.
+func (p fieldPair) isAllowed(conditions []bool) bool {
+	for _, i := range p.conditions {
+		if !conditions[i] {
+			return false
+		}
+	}
+	return true
+}
 
-	for _, sPath := range sourceStorage.GetAllPaths() {
-		sField := sourceStorage.MustFind(sPath)
-		dPath := getDestFieldName(sField.GetType(), sPath)
-		dField, ok := destStorage.Find(dPath)
+// getFieldPairs resolves source and destination fields which are mapped by names, skipped fields are not included.
+// Embedded structs of base routes are mapped as a whole, their nested fields are not matched.
+func getFieldPairs(sourceStorage, destStorage fmap.Storage, opt *options) []fieldPair {
+	var fields []fieldPair
+	for _, b := range opt.Bases {
+		srcFld, _ := findField(sourceStorage, b.sourcePath)
+		destFld, _ := findField(destStorage, b.destPath)
+		fields = append(fields, fieldPair{source: srcFld, dest: destFld, match: FieldMatchBase})
+	}
+	for _, sourcePath := range sourceStorage.GetAllPaths() {
+		if isFieldSelected(sourcePath, opt.Excluded) || opt.isBaseField(sourcePath, true) {
+			continue
+		}
+		destPath, match, ok := getDestFieldPath(destStorage, sourcePath, opt)
+		if !ok || opt.isDestFieldExcluded(destPath) || opt.isBaseField(destPath, false) {
+			continue
+		}
+		srcFld, _ := findField(sourceStorage, sourcePath)
+		destFld, _ := findField(destStorage, destPath)
+		pair := fieldPair{source: srcFld, dest: destFld, match: match}
+		for i, c := range opt.Conditions {
+			if c.field.matches(sourcePath) {
+				pair.conditions = append(pair.conditions, i)
+			}
+		}
+		fields = append(fields, pair)
+	}
+	return fields
+}
+
+// getDestFieldPath returns the renamed destination path, the same path or the flattened path of the source field.
+func getDestFieldPath(destStorage fmap.Storage, sourcePath string, opt *options) (string, FieldMatch, bool) {
+	for _, r := range opt.Renames {
+		if r.source.matches(sourcePath) {
+			destPath := r.dest.path + strings.TrimPrefix(sourcePath, r.source.path)
+			_, ok := destStorage.Find(destPath)
+			return destPath, FieldMatchRename, ok
+		}
+	}
+	candidates := []struct {
+		path  string
+		match FieldMatch
+	}{
+		{path: sourcePath, match: FieldMatchName},
+		{path: strings.ReplaceAll(sourcePath, ".", ""), match: FieldMatchFlatten},
+	}
+	for _, c := range candidates {
+		if opt.isRenameTarget(c.path) {
+			continue
+		}
+		if _, ok := destStorage.Find(c.path); ok {
+			return c.path, c.match, true
+		}
+	}
+	return "", FieldMatchName, false
+}
+
+// getSelectedPaths returns all paths of the storage selected by selectors.
+func getSelectedPaths(storage fmap.Storage, selectors []fieldSelector) []string {
+	var paths []string
+	for _, path := range storage.GetAllPaths() {
+		if isFieldSelected(path, selectors) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// getTagDefaults parses default values from `default` tags of destination fields.
+func getTagDefaults(destStorage fmap.Storage, opt *options) ([]fieldDefault, error) {
+	var defaults []fieldDefault
+	for _, path := range destStorage.GetAllPaths() {
+		if isFieldSelected(path, opt.DestExcluded) {
+			continue
+		}
+		fld, _ := findField(destStorage, path)
+		tag, ok := fld.GetTag().Lookup("default")
 		if !ok {
 			continue
 		}
-		err := setFieldRecursive(sField, dField, sourceStructField, destStructField)
+		newValue, err := parseDefault(fld.GetType(), tag)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("default tag of field %s: %w", path, err)
+		}
+		defaults = append(defaults, fieldDefault{
+			field: fieldSelector{path: path},
+			set: func(dest any) {
+				value := reflect.ValueOf(fld.GetPtr(dest)).Elem()
+				if value.IsZero() {
+					value.Set(newValue())
+				}
+			},
+		})
+	}
+	return defaults, nil
+}
+
+// parseDefault parses the default value of scalar type or pointer to scalar type,
+// returned func creates a new value to avoid sharing pointers between destinations.
+func parseDefault(typ reflect.Type, str string) (func() reflect.Value, error) {
+	valueType := typ
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	value := reflect.New(valueType).Elem()
+	switch valueType.Kind() {
+	case reflect.String:
+		value.SetString(str)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, valueType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, valueType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, valueType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("default value is not supported for type %s", typ)
+	}
+	if typ.Kind() != reflect.Ptr {
+		return func() reflect.Value {
+			return value
+		}, nil
+	}
+	return func() reflect.Value {
+		ptr := reflect.New(valueType)
+		ptr.Elem().Set(value)
+		return ptr
+	}, nil
+}
+
+// setField maps a single field, fields of nested structs are mapped by their own paths,
+// so struct fields are only mapped when a route for them exists.
+func setField(s *mapState, sourceFld, destFld fmap.Field, source, dest any) error {
+	if v, ok := sourceFld.(*virtualField); ok && !v.isSet(source) {
+		return nil
+	}
+	if v, ok := destFld.(*virtualField); ok {
+		return v.setFrom(s, sourceFld, source, dest)
+	}
+	mapping, r, err := getFieldMapping(sourceFld, destFld)
+	if err != nil {
+		return err
+	}
+	switch mapping {
+	case FieldMappingConverter:
+		sourceVal := getFieldValue(sourceFld, source)
+		if sourceVal == nil {
+			return nil
+		}
+		return r.mapFunc(s, sourceVal, destFld.GetPtr(dest))
+	case FieldMappingRoute:
+		sourceVal := getFieldValue(sourceFld, source)
+		if sourceVal == nil {
+			return nil
+		}
+		destPtr := destFld.GetPtr(dest)
+		return s.mapField(sourceFld.GetStructPath(), destPtr, func() error {
+			return r.mapFunc(s, sourceVal, destPtr)
+		})
+	case FieldMappingPointer:
+		sourceVal := getFieldValue(sourceFld, source)
+		if reflect.ValueOf(sourceVal).IsNil() {
+			return nil
+		}
+		return s.mapField(sourceFld.GetStructPath(), destFld.GetPtr(dest), func() error {
+			destPtr, err := s.mapPointer(sourceVal, destFld.GetType().Elem())
+			if err != nil {
+				return err
+			}
+			destFld.Set(dest, destPtr.Interface())
+			return nil
+		})
+	case FieldMappingDirect:
+		sourceVal := sourceFld.Get(source)
+		if sourceVal != nil {
+			destFld.Set(dest, sourceVal)
+		}
+	case FieldMappingNested:
+		// nested fields of flattened structs have no matching paths, so the struct is copied as a whole
+		if sourceFld.GetStructPath() != destFld.GetStructPath() {
+			destFld.Set(dest, sourceFld.Get(source))
 		}
 	}
 	return nil
 }
 
-func getRouteIfExists(sourceFld, destFld fmap.Field) (func(source interface{}, dest interface{}) error, bool) {
-	destType := destFld.GetType()
-	sourceType := sourceFld.GetType()
-	for sourceType.Kind() == reflect.Ptr {
-		sourceType = sourceType.Elem()
+// getFieldValue returns the value of the field with the field type, fmap returns values of named scalar types,
+// like time.Duration, and pointers to them with their underlying types.
+func getFieldValue(fld fmap.Field, obj any) any {
+	value := fld.Get(obj)
+	fieldType := fld.GetType()
+	if value != nil && fieldType.Kind() != reflect.Interface && reflect.TypeOf(value) != fieldType {
+		return reflect.ValueOf(value).Convert(fieldType).Interface()
+	}
+	return value
+}
+
+// getFieldMapping returns how the source field is mapped to the destination field and the route used for it.
+func getFieldMapping(sourceFld, destFld fmap.Field) (FieldMapping, *route, error) {
+	r, err := findRoute(sourceFld.GetType(), reflect.PointerTo(destFld.GetType()))
+	if err != nil {
+		return FieldMappingUnmapped, nil, err
+	}
+	if r != nil {
+		if r.info.Kind == RouteKindConverter {
+			return FieldMappingConverter, r, nil
+		}
+		return FieldMappingRoute, r, nil
+	}
+	if sourceFld.GetType().Kind() == reflect.Ptr && destFld.GetType().Kind() == reflect.Ptr {
+		// the pointer field is mapped with the route between pointed types
+		r, err = findRoute(sourceFld.GetType(), destFld.GetType())
+		if err != nil {
+			return FieldMappingUnmapped, nil, err
+		}
+		if r != nil {
+			return FieldMappingPointer, r, nil
+		}
+	}
+	if sourceFld.GetType() != destFld.GetType() {
+		return FieldMappingTypeMismatch, nil, nil
+	}
+	if sourceFld.GetType().Kind() == reflect.Struct {
+		return FieldMappingNested, nil, nil
 	}
-	destType = reflect.PointerTo(destType)
-	r, ok := routes[sourceType][destType]
-	return r, ok
+	return FieldMappingDirect, nil, nil
 }
 
-func getDestFieldName(sourceFieldType reflect.Type, sourceFieldName string) string {
-	if destFieldName, ok := manualFieldRoutes[sourceFieldType][sourceFieldName]; ok {
-		return destFieldName
+// nestedField is a struct field nested deeper than one level. fmap stores offsets of such fields
+// relative to the parent struct, so the field is accessed through the parent field pointer.
+type nestedField struct {
+	fmap.Field
+	parent fmap.Field
+}
+
+func (f *nestedField) Get(obj any) any {
+	return f.Field.Get(f.parent.GetPtr(obj))
+}
+
+func (f *nestedField) GetPtr(obj any) any {
+	return f.Field.GetPtr(f.parent.GetPtr(obj))
+}
+
+func (f *nestedField) Set(obj any, val any) {
+	f.Field.Set(f.parent.GetPtr(obj), val)
+}
+
+func findField(storage fmap.Storage, path string) (fmap.Field, bool) {
+	fld, ok := storage.Find(path)
+	if !ok {
+		return nil, false
+	}
+	i := strings.LastIndex(path, ".")
+	if i < 0 || fld.GetType().Kind() != reflect.Struct {
+		return fld, true
 	}
-	return sourceFieldName
+	parent, _ := findField(storage, path[:i])
+	return &nestedField{Field: fld, parent: parent}, true
 }
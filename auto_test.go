@@ -64,7 +64,7 @@ func TestAutoRoute(t *testing.T) {
 		assert.Equal(t, source.PtrTime, dest.PtrTime)
 	})
 	timeNow := time.Now()
-	_ = AutoRoute[AutoMappingStructSource, AutoMappingStructDest](WithFunc(func(source AutoMappingStructSource, dest *AutoMappingStructDest) {
+	_ = AutoRoute[AutoMappingStructSource, AutoMappingStructDest](WithReplace(), WithFunc(func(source AutoMappingStructSource, dest *AutoMappingStructDest) {
 		if source.Name == "Test1" {
 			dest.SecondName = "Test2"
 		}
@@ -121,3 +121,634 @@ func TestAutoRoute(t *testing.T) {
 		assert.Equal(t, source.NestedStruct.FirstNestedName, dest.NestedStruct.FirstNestedName)
 	})
 }
+
+type NamedScalarSource struct {
+	Timeout    time.Duration
+	PtrTimeout *time.Duration
+}
+
+type NamedScalarDest struct {
+	Timeout    string
+	PtrTimeout *string
+}
+
+func TestAutoRouteNamedScalarConverter(t *testing.T) {
+	assert.NoError(t, AddConverter(func(source time.Duration) (string, error) {
+		return source.String(), nil
+	}, WithReplace()))
+	assert.NoError(t, AutoRoute[NamedScalarSource, NamedScalarDest]())
+	timeout := time.Second
+	dest, err := MapTo[NamedScalarDest](NamedScalarSource{Timeout: time.Minute, PtrTimeout: &timeout})
+	assert.NoError(t, err)
+	assert.Equal(t, "1m0s", dest.Timeout)
+	assert.Equal(t, "1s", *dest.PtrTimeout)
+}
+
+type SkipStructSource struct {
+	Name         string
+	PasswordHash string
+	NestedStruct NestedStructSource
+}
+
+type SkipStructDest struct {
+	Name         string
+	PasswordHash string
+	NestedStruct NestedStructDest
+}
+
+func TestAutoRouteFieldSkip(t *testing.T) {
+	source := SkipStructSource{
+		Name:         "Test1",
+		PasswordHash: "hash",
+		NestedStruct: NestedStructSource{
+			FirstNestedName: "Test2",
+			DeepNestedStruct: DeepNestedStructSource{
+				SecondNestedName: "Test3",
+			},
+		},
+	}
+	t.Run("Skip destination field", func(t *testing.T) {
+		err := AutoRoute[SkipStructSource, SkipStructDest](
+			WithReplace(),
+			WithDestFieldSkip(func(dest *SkipStructDest) any {
+				return &dest.PasswordHash
+			}),
+		)
+		assert.NoError(t, err)
+		dest, err := MapTo[SkipStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, source.Name, dest.Name)
+		assert.Empty(t, dest.PasswordHash)
+		assert.Equal(t, source.NestedStruct.FirstNestedName, dest.NestedStruct.FirstNestedName)
+	})
+	t.Run("Skip destination field populated by func", func(t *testing.T) {
+		err := AutoRoute[SkipStructSource, SkipStructDest](
+			WithReplace(),
+			WithDestFieldSkip(func(dest *SkipStructDest) any {
+				return &dest.Name
+			}),
+			WithFunc(func(source SkipStructSource, dest *SkipStructDest) {
+				if dest.Name == "" {
+					dest.Name = "Custom" + source.Name
+				}
+			}),
+		)
+		assert.NoError(t, err)
+		dest, err := MapTo[SkipStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, "CustomTest1", dest.Name)
+	})
+	t.Run("Skip source nested struct with children", func(t *testing.T) {
+		err := AutoRoute[SkipStructSource, SkipStructDest](
+			WithReplace(),
+			WithFieldSkip(func(source *SkipStructSource) any {
+				return &source.NestedStruct
+			}),
+		)
+		assert.NoError(t, err)
+		dest, err := MapTo[SkipStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, source.Name, dest.Name)
+		assert.Equal(t, NestedStructDest{}, dest.NestedStruct)
+	})
+	t.Run("Skip destination nested struct with children", func(t *testing.T) {
+		err := AutoRoute[SkipStructSource, SkipStructDest](
+			WithReplace(),
+			WithDestFieldSkip(func(dest *SkipStructDest) any {
+				return &dest.NestedStruct.DeepNestedStruct
+			}),
+		)
+		assert.NoError(t, err)
+		dest, err := MapTo[SkipStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, source.NestedStruct.FirstNestedName, dest.NestedStruct.FirstNestedName)
+		assert.Empty(t, dest.NestedStruct.DeepNestedStruct.SecondNestedName)
+	})
+	t.Run("Skip selected on wrong type", func(t *testing.T) {
+		err := AutoRoute[SkipStructSource, SkipStructDest](
+			WithReplace(),
+			WithDestFieldSkip(func(dest *SkipStructSource) any {
+				return &dest.Name
+			}),
+		)
+		assert.Error(t, err)
+		err = AutoRoute[SkipStructSource, SkipStructDest](
+			WithReplace(),
+			WithFieldSkip(func(source *SkipStructDest) any {
+				return &source.Name
+			}),
+		)
+		assert.Error(t, err)
+	})
+	t.Run("Skip selected not a field", func(t *testing.T) {
+		name := "Test"
+		err := AutoRoute[SkipStructSource, SkipStructDest](
+			WithReplace(),
+			WithFieldSkip(func(source *SkipStructSource) any {
+				return &name
+			}),
+		)
+		assert.Error(t, err)
+	})
+}
+
+type ResolverStructSource struct {
+	FirstName    string
+	LastName     string
+	Name         string
+	NestedStruct NestedStructSource
+}
+
+type ResolverStructDest struct {
+	Name         string
+	NestedStruct NestedStructDest
+}
+
+func TestAutoRouteFieldResolver(t *testing.T) {
+	source := ResolverStructSource{
+		FirstName: "First",
+		LastName:  "Last",
+		Name:      "Name",
+		NestedStruct: NestedStructSource{
+			FirstNestedName: "Test1",
+		},
+	}
+	t.Run("Resolve field from source", func(t *testing.T) {
+		err := AutoRoute[ResolverStructSource, ResolverStructDest](
+			WithReplace(),
+			WithFieldResolver(func(dest *ResolverStructDest) *string {
+				return &dest.Name
+			}, func(source ResolverStructSource) (string, error) {
+				return source.FirstName + " " + source.LastName, nil
+			}),
+		)
+		assert.NoError(t, err)
+		dest, err := MapTo[ResolverStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, "First Last", dest.Name)
+		assert.Equal(t, source.NestedStruct.FirstNestedName, dest.NestedStruct.FirstNestedName)
+	})
+	t.Run("Resolve nested struct field replaces children mapping", func(t *testing.T) {
+		err := AutoRoute[ResolverStructSource, ResolverStructDest](
+			WithReplace(),
+			WithFieldResolver(func(dest *ResolverStructDest) *NestedStructDest {
+				return &dest.NestedStruct
+			}, func(source ResolverStructSource) (NestedStructDest, error) {
+				return NestedStructDest{FirstNestedSecondName: source.NestedStruct.FirstNestedName}, nil
+			}),
+		)
+		assert.NoError(t, err)
+		dest, err := MapTo[ResolverStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, source.Name, dest.Name)
+		assert.Empty(t, dest.NestedStruct.FirstNestedName)
+		assert.Equal(t, source.NestedStruct.FirstNestedName, dest.NestedStruct.FirstNestedSecondName)
+	})
+	t.Run("Resolver error", func(t *testing.T) {
+		err := AutoRoute[ResolverStructSource, ResolverStructDest](
+			WithReplace(),
+			WithFieldResolver(func(dest *ResolverStructDest) *string {
+				return &dest.Name
+			}, func(source ResolverStructSource) (string, error) {
+				return "", assert.AnError
+			}),
+		)
+		assert.NoError(t, err)
+		_, err = MapTo[ResolverStructDest](source)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("Resolver with wrong source type", func(t *testing.T) {
+		err := AutoRoute[ResolverStructSource, ResolverStructDest](
+			WithReplace(),
+			WithFieldResolver(func(dest *ResolverStructDest) *string {
+				return &dest.Name
+			}, func(source SkipStructSource) (string, error) {
+				return source.Name, nil
+			}),
+		)
+		assert.Error(t, err)
+	})
+}
+
+type ConditionStructSource struct {
+	Email         string
+	EmailVerified bool
+	Name          string
+	Age           int
+	NestedStruct  NestedStructSource
+}
+
+type ConditionStructDest struct {
+	Email        string
+	Name         string
+	Age          int
+	NestedStruct NestedStructDest
+}
+
+func TestAutoRouteCondition(t *testing.T) {
+	err := AutoRoute[ConditionStructSource, ConditionStructDest](
+		WithCondition(func(source *ConditionStructSource) any {
+			return &source.Email
+		}, func(source ConditionStructSource) bool {
+			return source.EmailVerified
+		}),
+		WithCondition(func(source *ConditionStructSource) any {
+			return &source.NestedStruct
+		}, func(source ConditionStructSource) bool {
+			return source.Age >= 18
+		}),
+		WithValueCondition(func(source *ConditionStructSource) *int {
+			return &source.Age
+		}, func(age int) bool {
+			return age > 0
+		}),
+	)
+	assert.NoError(t, err)
+	t.Run("Conditions are met", func(t *testing.T) {
+		source := ConditionStructSource{
+			Email:         "test@test.com",
+			EmailVerified: true,
+			Name:          "Test1",
+			Age:           20,
+			NestedStruct:  NestedStructSource{FirstNestedName: "Test2"},
+		}
+		dest, err := MapTo[ConditionStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, source.Email, dest.Email)
+		assert.Equal(t, source.Name, dest.Name)
+		assert.Equal(t, source.Age, dest.Age)
+		assert.Equal(t, source.NestedStruct.FirstNestedName, dest.NestedStruct.FirstNestedName)
+	})
+	t.Run("Conditions are not met", func(t *testing.T) {
+		source := ConditionStructSource{
+			Email:        "test@test.com",
+			Name:         "Test1",
+			Age:          -1,
+			NestedStruct: NestedStructSource{FirstNestedName: "Test2"},
+		}
+		dest := ConditionStructDest{Age: 10}
+		err := Map(source, &dest)
+		assert.NoError(t, err)
+		assert.Empty(t, dest.Email)
+		assert.Equal(t, source.Name, dest.Name)
+		assert.Equal(t, 10, dest.Age)
+		assert.Empty(t, dest.NestedStruct.FirstNestedName)
+	})
+	t.Run("Condition on wrong type", func(t *testing.T) {
+		err := AutoRoute[ConditionStructSource, ConditionStructDest](
+			WithCondition(func(dest *ConditionStructDest) any {
+				return &dest.Email
+			}, func(dest ConditionStructDest) bool {
+				return true
+			}),
+		)
+		assert.Error(t, err)
+	})
+}
+
+type PointerShapedSource struct {
+	Name *string
+}
+
+type PointerShapedDest struct {
+	Name  *string
+	Title string
+}
+
+func TestAutoRoutePointerShapedSource(t *testing.T) {
+	err := AutoRoute[PointerShapedSource, PointerShapedDest](
+		WithFieldResolver(func(dest *PointerShapedDest) *string {
+			return &dest.Title
+		}, func(source PointerShapedSource) (string, error) {
+			return "Dr. " + *source.Name, nil
+		}),
+		WithCondition(func(source *PointerShapedSource) any {
+			return &source.Name
+		}, func(source PointerShapedSource) bool {
+			return *source.Name != ""
+		}),
+	)
+	assert.NoError(t, err)
+	name := "John"
+	dest, err := MapTo[PointerShapedDest](PointerShapedSource{Name: &name})
+	assert.NoError(t, err)
+	assert.Equal(t, &name, dest.Name)
+	assert.Equal(t, "Dr. John", dest.Title)
+}
+
+type PointerStructSource struct {
+	Name string
+}
+
+type PointerStructDest struct {
+	Name  string
+	Title string `default:"Mr"`
+}
+
+func TestAutoRoutePointerStructs(t *testing.T) {
+	assert.NoError(t, AutoRouteBidirectional[*PointerStructSource, *PointerStructDest]())
+
+	t.Run("Source pointer", func(t *testing.T) {
+		dest, err := MapTo[*PointerStructDest](&PointerStructSource{Name: "John"})
+		assert.NoError(t, err)
+		assert.Equal(t, &PointerStructDest{Name: "John", Title: "Mr"}, dest)
+		dest, err = MapTo[*PointerStructDest](PointerStructSource{Name: "Jane"})
+		assert.NoError(t, err)
+		assert.Equal(t, &PointerStructDest{Name: "Jane", Title: "Mr"}, dest)
+	})
+	t.Run("Destination is written through the pointer", func(t *testing.T) {
+		dest := &PointerStructDest{Title: "Dr"}
+		ptr := dest
+		assert.NoError(t, Map(&PointerStructSource{Name: "John"}, &ptr))
+		assert.Same(t, dest, ptr)
+		assert.Equal(t, PointerStructDest{Name: "John", Title: "Dr"}, *dest)
+
+		source := PointerStructSource{}
+		assert.NoError(t, Map(PointerStructDest{Name: "Jane"}, &source))
+		assert.Equal(t, PointerStructSource{Name: "Jane"}, source)
+	})
+	t.Run("MapAs", func(t *testing.T) {
+		dest, err := MapAs[*PointerStructSource, *PointerStructDest](&PointerStructSource{Name: "John"})
+		assert.NoError(t, err)
+		assert.Equal(t, &PointerStructDest{Name: "John", Title: "Mr"}, dest)
+	})
+}
+
+type DefaultStructSource struct {
+	Name    string
+	PtrTime *time.Time
+	Count   int
+}
+
+type DefaultNestedStructDest struct {
+	Enabled bool `default:"true"`
+}
+
+type DefaultStructDest struct {
+	Name         string  `default:"Unknown"`
+	Status       string  `default:"new"`
+	PtrName      *string `default:"PtrUnknown"`
+	Count        uint16  `default:"10"`
+	Ratio        float64 `default:"0.5"`
+	PtrTime      *time.Time
+	NestedStruct DefaultNestedStructDest
+}
+
+type InvalidDefaultStructDest struct {
+	Count int `default:"ten"`
+}
+
+func TestAutoRouteDefault(t *testing.T) {
+	timeNow := time.Now()
+	err := AutoRoute[DefaultStructSource, DefaultStructDest](
+		WithDefault(func(dest *DefaultStructDest) **time.Time {
+			return &dest.PtrTime
+		}, &timeNow),
+		WithDefault(func(dest *DefaultStructDest) *string {
+			return &dest.Status
+		}, "draft"),
+	)
+	assert.NoError(t, err)
+	t.Run("Defaults for unset fields", func(t *testing.T) {
+		dest, err := MapTo[DefaultStructDest](DefaultStructSource{})
+		assert.NoError(t, err)
+		assert.Equal(t, "Unknown", dest.Name)
+		assert.Equal(t, "draft", dest.Status)
+		assert.Equal(t, "PtrUnknown", *dest.PtrName)
+		assert.Equal(t, uint16(10), dest.Count)
+		assert.Equal(t, 0.5, dest.Ratio)
+		assert.Equal(t, &timeNow, dest.PtrTime)
+		assert.True(t, dest.NestedStruct.Enabled)
+	})
+	t.Run("Defaults are not applied to set fields", func(t *testing.T) {
+		ptrTime := time.Now().Add(time.Hour)
+		source := DefaultStructSource{Name: "Test1", PtrTime: &ptrTime}
+		dest, err := MapTo[DefaultStructDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, source.Name, dest.Name)
+		assert.Equal(t, source.PtrTime, dest.PtrTime)
+	})
+	t.Run("Default pointers are not shared", func(t *testing.T) {
+		first, err := MapTo[DefaultStructDest](DefaultStructSource{})
+		assert.NoError(t, err)
+		second, err := MapTo[DefaultStructDest](DefaultStructSource{})
+		assert.NoError(t, err)
+		assert.NotSame(t, first.PtrName, second.PtrName)
+	})
+	t.Run("Invalid default tag", func(t *testing.T) {
+		err := AutoRoute[DefaultStructSource, InvalidDefaultStructDest]()
+		assert.Error(t, err)
+	})
+}
+
+type ReverseAddress struct {
+	City   string
+	Street string
+}
+
+type ReverseEntity struct {
+	ID       int
+	Name     string
+	Password string
+	Address  ReverseAddress
+}
+
+type ReverseDTO struct {
+	ID            int
+	FullName      string
+	Password      string
+	AddressCity   string
+	AddressStreet string
+}
+
+func TestAutoRouteRename(t *testing.T) {
+	err := AutoRoute[ReverseEntity, ReverseDTO](
+		WithReplace(),
+		WithFieldRename(func(source *ReverseEntity) any {
+			return &source.Name
+		}, func(dest *ReverseDTO) any {
+			return &dest.FullName
+		}),
+	)
+	assert.NoError(t, err)
+	source := ReverseEntity{ID: 1, Name: "Test1", Address: ReverseAddress{City: "City", Street: "Street"}}
+	dest, err := MapTo[ReverseDTO](source)
+	assert.NoError(t, err)
+	assert.Equal(t, source.Name, dest.FullName)
+	assert.Equal(t, source.Address.City, dest.AddressCity)
+	assert.Equal(t, source.Address.Street, dest.AddressStreet)
+
+	t.Run("Rename on wrong type", func(t *testing.T) {
+		err := AutoRoute[ReverseEntity, ReverseDTO](
+			WithReplace(),
+			WithFieldRename(func(source *ReverseDTO) any {
+				return &source.FullName
+			}, func(dest *ReverseEntity) any {
+				return &dest.Name
+			}),
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestAutoRouteBidirectional(t *testing.T) {
+	t.Run("Renames, flattening and skips are inverted", func(t *testing.T) {
+		err := AutoRouteBidirectional[ReverseEntity, ReverseDTO](
+			WithReplace(),
+			WithFieldRename(func(source *ReverseEntity) any {
+				return &source.Name
+			}, func(dest *ReverseDTO) any {
+				return &dest.FullName
+			}),
+			WithFieldSkip(func(source *ReverseEntity) any {
+				return &source.Password
+			}),
+		)
+		assert.NoError(t, err)
+		dto := ReverseDTO{ID: 1, FullName: "Test1", Password: "secret", AddressCity: "City", AddressStreet: "Street"}
+		entity, err := MapTo[ReverseEntity](dto)
+		assert.NoError(t, err)
+		assert.Equal(t, dto.ID, entity.ID)
+		assert.Equal(t, dto.FullName, entity.Name)
+		assert.Empty(t, entity.Password)
+		assert.Equal(t, dto.AddressCity, entity.Address.City)
+		assert.Equal(t, dto.AddressStreet, entity.Address.Street)
+
+		dest, err := MapTo[ReverseDTO](entity)
+		assert.NoError(t, err)
+		assert.Equal(t, ReverseDTO{ID: 1, FullName: "Test1", AddressCity: "City", AddressStreet: "Street"}, dest)
+	})
+	t.Run("Not invertible options are reported", func(t *testing.T) {
+		err := AutoRoute[ReverseEntity, ReverseDTO](
+			WithReplace(),
+			ReverseMap(),
+			WithFunc(func(source ReverseEntity, dest *ReverseDTO) {
+				dest.FullName = source.Name + "!"
+			}),
+			WithDefault(func(dest *ReverseDTO) *string {
+				return &dest.Password
+			}, "default"),
+		)
+		assert.NoError(t, err)
+		explanation, err := ExplainRoute[ReverseDTO, ReverseEntity]()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"WithFunc", "default of field Password"}, explanation.NotInverted)
+		assert.Contains(t, explanation.String(), "not inverted: WithFunc, default of field Password")
+
+		dest, err := MapTo[ReverseDTO](ReverseEntity{Name: "Test1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1!", dest.FullName)
+		assert.Equal(t, "default", dest.Password)
+		entity, err := MapTo[ReverseEntity](ReverseDTO{AddressCity: "City"})
+		assert.NoError(t, err)
+		assert.Equal(t, "City", entity.Address.City)
+		assert.Empty(t, entity.Password)
+	})
+}
+
+type BaseUser struct {
+	ID       int
+	Name     string
+	Password string
+}
+
+type BaseUserDTO struct {
+	ID       int
+	FullName string
+	Password string
+	Source   string
+}
+
+type DerivedAdminUser struct {
+	BaseUser
+	Level int
+}
+
+type DerivedAdminUserDTO struct {
+	BaseUserDTO
+	Level int
+}
+
+type DerivedPointerAdminUser struct {
+	*BaseUser
+	Level int
+}
+
+func TestAutoRouteIncludeBase(t *testing.T) {
+	err := AutoRoute[BaseUser, BaseUserDTO](
+		WithFieldRename(func(source *BaseUser) any {
+			return &source.Name
+		}, func(dest *BaseUserDTO) any {
+			return &dest.FullName
+		}),
+		WithFieldSkip(func(source *BaseUser) any {
+			return &source.Password
+		}),
+		WithFunc(func(source BaseUser, dest *BaseUserDTO) {
+			dest.Source = "base"
+		}),
+	)
+	assert.NoError(t, err)
+
+	t.Run("Base route is applied to embedded struct", func(t *testing.T) {
+		err := AutoRoute[DerivedAdminUser, DerivedAdminUserDTO](IncludeBase[BaseUser, BaseUserDTO]())
+		assert.NoError(t, err)
+		source := DerivedAdminUser{BaseUser: BaseUser{ID: 1, Name: "Test1", Password: "secret"}, Level: 2}
+		dest, err := MapTo[DerivedAdminUserDTO](source)
+		assert.NoError(t, err)
+		assert.Equal(t, DerivedAdminUserDTO{
+			BaseUserDTO: BaseUserDTO{ID: 1, FullName: "Test1", Source: "base"},
+			Level:       2,
+		}, dest)
+	})
+	t.Run("Pointer to embedded struct", func(t *testing.T) {
+		err := AutoRoute[DerivedPointerAdminUser, DerivedAdminUserDTO](IncludeBase[BaseUser, BaseUserDTO]())
+		assert.NoError(t, err)
+		dest, err := MapTo[DerivedAdminUserDTO](DerivedPointerAdminUser{BaseUser: &BaseUser{ID: 1, Name: "Test1"}, Level: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1", dest.FullName)
+		assert.Equal(t, 2, dest.Level)
+		dest, err = MapTo[DerivedAdminUserDTO](DerivedPointerAdminUser{Level: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, DerivedAdminUserDTO{Level: 3}, dest)
+	})
+	t.Run("Explain", func(t *testing.T) {
+		explanation, err := ExplainRoute[DerivedAdminUser, DerivedAdminUserDTO]()
+		assert.NoError(t, err)
+		assert.Contains(t, explanation.Fields, FieldExplanation{
+			DestPath: "BaseUserDTO", SourcePath: "BaseUser", Mapping: FieldMappingRoute, Match: FieldMatchBase,
+		})
+		assert.Contains(t, explanation.Fields, FieldExplanation{
+			DestPath: "BaseUserDTO.FullName", SourcePath: "BaseUser", Mapping: FieldMappingRoute, Match: FieldMatchBase,
+		})
+	})
+	t.Run("Base type is not embedded", func(t *testing.T) {
+		err := AutoRoute[DerivedAdminUser, BaseUserDTO](IncludeBase[BaseUser, BaseUserDTO]())
+		assert.ErrorContains(t, err, "is not embedded")
+	})
+	t.Run("Base route not found", func(t *testing.T) {
+		err := AutoRoute[DerivedAdminUser, DerivedAdminUserDTO](WithReplace(), ReverseMap(),
+			IncludeBase[BaseUser, BaseUserDTO]())
+		assert.ErrorContains(t, err, "route not found")
+	})
+}
+
+type FlattenAudit struct {
+	CreatedAt time.Time
+}
+
+type FlattenStructSource struct {
+	Audit FlattenAudit
+}
+
+type FlattenStructDest struct {
+	AuditCreatedAt time.Time
+}
+
+func TestAutoRouteFlattenStruct(t *testing.T) {
+	assert.NoError(t, AutoRoute[FlattenStructSource, FlattenStructDest]())
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	dest, err := MapTo[FlattenStructDest](FlattenStructSource{Audit: FlattenAudit{CreatedAt: createdAt}})
+	assert.NoError(t, err)
+	assert.Equal(t, createdAt, dest.AuditCreatedAt)
+}
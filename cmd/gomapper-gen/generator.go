@@ -0,0 +1,449 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const routeDirective = "//gomapper:route"
+
+// genRoute is the route declared with the directive.
+type genRoute struct {
+	source   *types.Named
+	dest     *types.Named
+	funcName string
+}
+
+// fieldPath is the field of the struct or nested struct, paths are the same as fmap paths used by AutoRoute.
+type fieldPath struct {
+	path string
+	typ  types.Type
+}
+
+type generator struct {
+	pkg      *types.Package
+	routes   []*genRoute
+	imports  map[string]*types.Package
+	warnings []string
+}
+
+// generate returns the source of the generated file for the package in dir and warnings about unmapped fields.
+func generate(dir, output, funcName string) ([]byte, []string, error) {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir, output)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no go files found in %s", dir)
+	}
+	var typeErrs []types.Error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// the package may refer to the generated file, which is not parsed, so errors are collected
+		// and checked after directives are parsed, types are resolved as far as possible
+		Error: func(err error) {
+			if typeErr, ok := err.(types.Error); ok {
+				typeErrs = append(typeErrs, typeErr)
+			}
+		},
+	}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+	g := &generator{pkg: pkg, imports: map[string]*types.Package{}}
+	for _, file := range files {
+		if err = g.parseDirectives(fset, file); err != nil {
+			return nil, g.warnings, err
+		}
+	}
+	if len(g.routes) == 0 {
+		return nil, nil, fmt.Errorf("no %s directives found in %s", routeDirective, dir)
+	}
+	if err = g.checkTypeErrors(typeErrs, funcName); err != nil {
+		return nil, g.warnings, err
+	}
+	src, err := g.generate(funcName)
+	return src, g.warnings, err
+}
+
+// parseDir parses non-test go files of the directory except the generated file.
+func parseDir(fset *token.FileSet, dir, output string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == output || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// checkTypeErrors reports type errors of the package as warnings, an error is returned when fields of routed types
+// have unresolved types. References to functions of the generated file, which is not parsed, are not reported.
+func (g *generator) checkTypeErrors(typeErrs []types.Error, funcName string) error {
+	generated := map[string]bool{funcName: true}
+	for _, r := range g.routes {
+		generated[r.funcName] = true
+	}
+	var reported []string
+	for _, e := range typeErrs {
+		if name, ok := strings.CutPrefix(e.Msg, "undefined: "); ok && generated[name] {
+			continue
+		}
+		reported = append(reported, e.Error())
+	}
+	if len(reported) == 0 {
+		return nil
+	}
+	for _, r := range g.routes {
+		for _, named := range []*types.Named{r.source, r.dest} {
+			for _, p := range structPaths(named.Underlying().(*types.Struct), "", g.pkg) {
+				if isInvalid(p.typ) {
+					return fmt.Errorf("type of field %s.%s is not resolved:\n%s",
+						g.typeString(named), p.path, strings.Join(reported, "\n"))
+				}
+			}
+		}
+	}
+	for _, e := range reported {
+		g.warnings = append(g.warnings, "type error: "+e)
+	}
+	return nil
+}
+
+// isInvalid reports whether the type or its element types are not resolved by the type checker.
+func isInvalid(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.Kind() == types.Invalid
+	case *types.Pointer:
+		return isInvalid(t.Elem())
+	case *types.Slice:
+		return isInvalid(t.Elem())
+	case *types.Array:
+		return isInvalid(t.Elem())
+	case *types.Map:
+		return isInvalid(t.Key()) || isInvalid(t.Elem())
+	default:
+		return false
+	}
+}
+
+func (g *generator) parseDirectives(fset *token.FileSet, file *ast.File) error {
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, routeDirective) {
+				continue
+			}
+			r, err := g.parseRoute(file, strings.TrimPrefix(c.Text, routeDirective))
+			if err != nil {
+				return fmt.Errorf("%s: %w", fset.Position(c.Pos()), err)
+			}
+			g.routes = append(g.routes, r)
+		}
+	}
+	return nil
+}
+
+func (g *generator) parseRoute(file *ast.File, text string) (*genRoute, error) {
+	parts := strings.Split(text, "->")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid directive %q, expected %s Source -> Dest", text, routeDirective)
+	}
+	source, err := g.lookupStruct(file, strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, err
+	}
+	dest, err := g.lookupStruct(file, strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range g.routes {
+		if types.Identical(r.source, source) && types.Identical(r.dest, dest) {
+			return nil, fmt.Errorf("duplicate route %s -> %s", parts[0], parts[1])
+		}
+	}
+	return &genRoute{
+		source:   source,
+		dest:     dest,
+		funcName: "map" + g.funcNamePart(source) + "To" + g.funcNamePart(dest),
+	}, nil
+}
+
+// lookupStruct finds the struct type declared in the package or in the package imported by the file.
+func (g *generator) lookupStruct(file *ast.File, name string) (*types.Named, error) {
+	pkg := g.pkg
+	typeName := name
+	if i := strings.Index(name, "."); i >= 0 {
+		pkg = g.findImport(file, name[:i])
+		if pkg == nil {
+			return nil, fmt.Errorf("package %s of type %s is not imported", name[:i], name)
+		}
+		typeName = name[i+1:]
+	}
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", name)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a named type", name)
+	}
+	if _, ok = named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("type %s is not a struct", name)
+	}
+	return named, nil
+}
+
+func (g *generator) findImport(file *ast.File, name string) *types.Package {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		for _, imported := range g.pkg.Imports() {
+			if imported.Path() != path {
+				continue
+			}
+			if (spec.Name != nil && spec.Name.Name == name) || (spec.Name == nil && imported.Name() == name) {
+				return imported
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) funcNamePart(named *types.Named) string {
+	name := named.Obj().Name()
+	if named.Obj().Pkg() == g.pkg {
+		return name
+	}
+	pkgName := named.Obj().Pkg().Name()
+	return strings.ToUpper(pkgName[:1]) + pkgName[1:] + name
+}
+
+// qualifier qualifies types of other packages and remembers them to import.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg
+	return pkg.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+func (g *generator) findRoute(source, dest types.Type) *genRoute {
+	for _, r := range g.routes {
+		if types.Identical(r.source, source) && types.Identical(r.dest, dest) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (g *generator) generate(funcName string) ([]byte, error) {
+	body := &bytes.Buffer{}
+	fmt.Fprintf(body, "// %s registers generated mapping functions with gomapper.AddRoute.\n", funcName)
+	fmt.Fprintf(body, "func %s(opts ...gomapper.RouteOption) error {\n", funcName)
+	for _, r := range g.routes {
+		fmt.Fprintf(body, "if err := gomapper.AddRoute(%s, opts...); err != nil {\nreturn err\n}\n", r.funcName)
+	}
+	body.WriteString("return nil\n}\n")
+	for _, r := range g.routes {
+		g.writeRoute(body, r)
+	}
+
+	src := &bytes.Buffer{}
+	src.WriteString("// Code generated by gomapper-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(src, "package %s\n\n", g.pkg.Name())
+	paths := []string{"github.com/insei/gomapper"}
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	src.WriteString("import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(src, "%q\n", path)
+	}
+	src.WriteString(")\n\n")
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+func (g *generator) writeRoute(w *bytes.Buffer, r *genRoute) {
+	sourceName := g.typeString(r.source)
+	destName := g.typeString(r.dest)
+	fmt.Fprintf(w, "\n// %s maps %s to %s.\n", r.funcName, sourceName, destName)
+	fmt.Fprintf(w, "func %s(source %s, dest *%s) error {\n", r.funcName, sourceName, destName)
+
+	destPaths := map[string]types.Type{}
+	for _, p := range structPaths(r.dest.Underlying().(*types.Struct), "", g.pkg) {
+		destPaths[p.path] = p.typ
+	}
+	var assigned []string
+	for _, p := range structPaths(r.source.Underlying().(*types.Struct), "", g.pkg) {
+		if hasPathPrefix(p.path, assigned) {
+			// nested fields of the assigned struct are already copied
+			continue
+		}
+		destPath := p.path
+		destType, ok := destPaths[destPath]
+		if !ok {
+			destPath = strings.ReplaceAll(p.path, ".", "")
+			if destType, ok = destPaths[destPath]; !ok {
+				continue
+			}
+		}
+		if g.writeField(w, p.path, destPath, p.typ, destType) {
+			assigned = append(assigned, p.path)
+		}
+	}
+	w.WriteString("return nil\n}\n")
+}
+
+// writeField writes the mapping of the field, it reports whether the whole field value is assigned.
+func (g *generator) writeField(w *bytes.Buffer, sourcePath, destPath string, sourceType, destType types.Type) bool {
+	source := "source." + sourcePath
+	dest := "dest." + destPath
+	if fr := g.findRoute(sourceType, destType); fr != nil {
+		fmt.Fprintf(w, "if err := %s(%s, &%s); err != nil {\nreturn err\n}\n", fr.funcName, source, dest)
+		return false
+	}
+	if types.Identical(sourceType, destType) {
+		fmt.Fprintf(w, "%s = %s\n", dest, source)
+		return true
+	}
+	if sourcePtr, ok := sourceType.(*types.Pointer); ok {
+		if destPtr, ok := destType.(*types.Pointer); ok {
+			if fr := g.findRoute(sourcePtr.Elem(), destPtr.Elem()); fr != nil {
+				fmt.Fprintf(w, "if %s != nil {\n%s = new(%s)\nif err := %s(*%s, %s); err != nil {\nreturn err\n}\n}\n",
+					source, dest, g.typeString(destPtr.Elem()), fr.funcName, source, dest)
+				return false
+			}
+		}
+	}
+	if sourceSlice, ok := sourceType.(*types.Slice); ok {
+		if destSlice, ok := destType.(*types.Slice); ok && g.writeSlice(w, source, dest, sourceSlice.Elem(), destSlice.Elem()) {
+			return false
+		}
+	}
+	_, sourceStruct := sourceType.Underlying().(*types.Struct)
+	_, destStruct := destType.Underlying().(*types.Struct)
+	if sourceStruct && destStruct {
+		// nested fields are matched by their own paths
+		return false
+	}
+	g.writeRuntimeField(w, source, dest, sourceType, destType)
+	return false
+}
+
+// writeRuntimeField writes the mapping of the field without the generated route with gomapper.Map, so routes
+// registered at runtime, like converters, wrappers and routes from interfaces, are used like by AutoRoute.
+// Fields without routes at runtime are not mapped, nil pointers and interfaces are not mapped too.
+func (g *generator) writeRuntimeField(w *bytes.Buffer, source, dest string, sourceType, destType types.Type) {
+	sourceName, destName := g.typeString(sourceType), g.typeString(destType)
+	fmt.Fprintf(w, "// %s is mapped at runtime, no generated route from %s to %s\n", strings.TrimPrefix(dest, "dest."),
+		sourceName, destName)
+	mapCall := fmt.Sprintf("if err := gomapper.Map(%s, &%s); err != nil {\nreturn err\n}\n", source, dest)
+	if !isNillable(sourceType) {
+		fmt.Fprintf(w, "if gomapper.HasRoute[%s, %s]() {\n%s}\n", sourceName, destName, mapCall)
+		return
+	}
+	fmt.Fprintf(w, "if %s != nil {\n", source)
+	fmt.Fprintf(w, "if gomapper.HasRoute[%s, %s]() {\n%s}", sourceName, destName, mapCall)
+	if destPtr, ok := destType.(*types.Pointer); ok {
+		// the pointer field is mapped with the route between pointed types
+		elemName := g.typeString(destPtr.Elem())
+		fmt.Fprintf(w, " else if gomapper.HasRoute[%s, %s]() {\n%s = new(%s)\n", sourceName, elemName, dest, elemName)
+		fmt.Fprintf(w, "if err := gomapper.Map(%s, %s); err != nil {\nreturn err\n}\n}", source, dest)
+	}
+	w.WriteString("\n}\n")
+}
+
+// isNillable reports whether nil values of the type are not mapped by AutoRoute.
+func isNillable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeSlice writes the mapping of slices of routed types, elements are values or pointers in both slices.
+func (g *generator) writeSlice(w *bytes.Buffer, source, dest string, sourceElem, destElem types.Type) bool {
+	if fr := g.findRoute(sourceElem, destElem); fr != nil {
+		fmt.Fprintf(w, "%s = make([]%s, 0, len(%s))\n", dest, g.typeString(destElem), source)
+		fmt.Fprintf(w, "for _, item := range %s {\nvar value %s\n", source, g.typeString(destElem))
+		fmt.Fprintf(w, "if err := %s(item, &value); err != nil {\nreturn err\n}\n", fr.funcName)
+		fmt.Fprintf(w, "%s = append(%s, value)\n}\n", dest, dest)
+		return true
+	}
+	sourcePtr, ok := sourceElem.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	destPtr, ok := destElem.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	fr := g.findRoute(sourcePtr.Elem(), destPtr.Elem())
+	if fr == nil {
+		return false
+	}
+	fmt.Fprintf(w, "%s = make([]%s, 0, len(%s))\n", dest, g.typeString(destElem), source)
+	fmt.Fprintf(w, "for _, item := range %s {\nif item == nil {\n%s = append(%s, nil)\ncontinue\n}\n", source, dest, dest)
+	fmt.Fprintf(w, "value := new(%s)\n", g.typeString(destPtr.Elem()))
+	fmt.Fprintf(w, "if err := %s(*item, value); err != nil {\nreturn err\n}\n", fr.funcName)
+	fmt.Fprintf(w, "%s = append(%s, value)\n}\n", dest, dest)
+	return true
+}
+
+// structPaths returns paths of struct fields and fields of nested structs, pointers are not followed.
+// Unexported fields of other packages are not accessible and skipped.
+func structPaths(st *types.Struct, prefix string, pkg *types.Package) []fieldPath {
+	var paths []fieldPath
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if !f.Exported() && f.Pkg() != pkg {
+			continue
+		}
+		path := prefix + f.Name()
+		paths = append(paths, fieldPath{path: path, typ: f.Type()})
+		if nested, ok := f.Type().Underlying().(*types.Struct); ok {
+			paths = append(paths, structPaths(nested, path+".", pkg)...)
+		}
+	}
+	return paths
+}
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
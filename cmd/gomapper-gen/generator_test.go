@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("Generated file is up to date", func(t *testing.T) {
+		dir := filepath.Join("internal", "example")
+		src, warnings, err := generate(dir, "gomapper_gen.go", "AddGeneratedRoutes")
+		assert.NoError(t, err)
+		expected, err := os.ReadFile(filepath.Join(dir, "gomapper_gen.go"))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(src))
+		assert.Empty(t, warnings)
+	})
+	t.Run("Invalid directives", func(t *testing.T) {
+		cases := map[string]string{
+			"//gomapper:route Source Dest":        "invalid directive",
+			"//gomapper:route Source -> Missing":  "type Missing not found",
+			"//gomapper:route Source -> Name":     "type Name is not a struct",
+			"//gomapper:route Source -> dto.Dest": "package dto of type dto.Dest is not imported",
+		}
+		for directive, expected := range cases {
+			dir := t.TempDir()
+			src := "package test\n\n" + directive + "\n\ntype Source struct{}\n\ntype Name string\n"
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644))
+			_, _, err := generate(dir, "gomapper_gen.go", "AddGeneratedRoutes")
+			assert.ErrorContains(t, err, expected)
+		}
+	})
+	t.Run("Type errors", func(t *testing.T) {
+		dir := t.TempDir()
+		src := "package test\n\n//gomapper:route Source -> Dest\n\ntype Source struct {\n\tName Missing\n}\n\ntype Dest struct {\n\tName string\n}\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644))
+		_, _, err := generate(dir, "gomapper_gen.go", "AddGeneratedRoutes")
+		assert.ErrorContains(t, err, "type of field Source.Name is not resolved")
+		assert.ErrorContains(t, err, "undefined: Missing")
+
+		src = "package test\n\n//gomapper:route Source -> Dest\n\ntype Source struct {\n\tName string\n}\n\ntype Dest struct {\n\tName string\n}\n\n" +
+			"func init() {\n\t_ = AddGeneratedRoutes()\n\t_ = missing\n}\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644))
+		_, warnings, err := generate(dir, "gomapper_gen.go", "AddGeneratedRoutes")
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "undefined: missing")
+	})
+	t.Run("No directives", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), []byte("package test\n"), 0o644))
+		_, _, err := generate(dir, "gomapper_gen.go", "AddGeneratedRoutes")
+		assert.ErrorContains(t, err, "no //gomapper:route directives found")
+	})
+}
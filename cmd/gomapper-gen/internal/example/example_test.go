@@ -0,0 +1,53 @@
+package example
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/insei/gomapper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedRoutes(t *testing.T) {
+	source := User{
+		ID:       1,
+		Name:     "Test1",
+		Tags:     []string{"tag1"},
+		Audit:    Audit{CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), CreatedBy: "admin"},
+		Address:  Address{City: "City1", Street: "Street1"},
+		Billing:  &Address{City: "City2"},
+		Previous: []Address{{City: "City3"}},
+		Contacts: []*Address{{City: "City4"}, nil},
+		Backup:   []Address{{City: "City5"}},
+		Meta:     map[string]string{"key": "value"},
+		Score:    1.5,
+		Nickname: sql.NullString{String: "Nick", Valid: true},
+		Reviewed: &Audit{CreatedBy: "reviewer"},
+	}
+	// fields without generated routes are mapped with routes registered at runtime
+	assert.NoError(t, gomapper.AddConverter(func(source float64) (int, error) { return int(source), nil }))
+	assert.NoError(t, gomapper.AutoRoute[Audit, AuditDTO]())
+	assert.NoError(t, gomapper.AutoRoute[Address, AddressDTO]())
+	generated := UserDTO{}
+	assert.NoError(t, mapUserToUserDTO(source, &generated))
+	assert.Equal(t, 1, generated.Score)
+	assert.Equal(t, "Nick", *generated.Nickname)
+	assert.Equal(t, "reviewer", generated.Reviewed.CreatedBy)
+	assert.Equal(t, "City5", generated.Backup[0].City)
+
+	t.Run("Generated code maps like AutoRoute", func(t *testing.T) {
+		assert.NoError(t, gomapper.AutoRoute[User, UserDTO]())
+		dest, err := gomapper.MapTo[UserDTO](source)
+		assert.NoError(t, err)
+		assert.Equal(t, dest, generated)
+	})
+	t.Run("Generated routes registration", func(t *testing.T) {
+		assert.NoError(t, AddGeneratedRoutes(gomapper.WithReplace()))
+		dest, err := gomapper.MapTo[UserDTO](source)
+		assert.NoError(t, err)
+		assert.Equal(t, generated, dest)
+		assert.Equal(t, "City4", dest.Contacts[0].City)
+		assert.Nil(t, dest.Contacts[1])
+	})
+}
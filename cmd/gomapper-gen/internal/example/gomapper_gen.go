@@ -0,0 +1,99 @@
+// Code generated by gomapper-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"database/sql"
+	"github.com/insei/gomapper"
+)
+
+// AddGeneratedRoutes registers generated mapping functions with gomapper.AddRoute.
+func AddGeneratedRoutes(opts ...gomapper.RouteOption) error {
+	if err := gomapper.AddRoute(mapUserToUserDTO, opts...); err != nil {
+		return err
+	}
+	if err := gomapper.AddRoute(mapAddressToAddressDTO, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mapUserToUserDTO maps User to UserDTO.
+func mapUserToUserDTO(source User, dest *UserDTO) error {
+	dest.ID = source.ID
+	dest.Name = source.Name
+	dest.Tags = source.Tags
+	dest.AuditCreatedAt = source.Audit.CreatedAt
+	dest.AuditCreatedBy = source.Audit.CreatedBy
+	if err := mapAddressToAddressDTO(source.Address, &dest.Address); err != nil {
+		return err
+	}
+	dest.Address.City = source.Address.City
+	dest.Address.Street = source.Address.Street
+	if source.Billing != nil {
+		dest.Billing = new(AddressDTO)
+		if err := mapAddressToAddressDTO(*source.Billing, dest.Billing); err != nil {
+			return err
+		}
+	}
+	dest.Previous = make([]AddressDTO, 0, len(source.Previous))
+	for _, item := range source.Previous {
+		var value AddressDTO
+		if err := mapAddressToAddressDTO(item, &value); err != nil {
+			return err
+		}
+		dest.Previous = append(dest.Previous, value)
+	}
+	dest.Contacts = make([]*AddressDTO, 0, len(source.Contacts))
+	for _, item := range source.Contacts {
+		if item == nil {
+			dest.Contacts = append(dest.Contacts, nil)
+			continue
+		}
+		value := new(AddressDTO)
+		if err := mapAddressToAddressDTO(*item, value); err != nil {
+			return err
+		}
+		dest.Contacts = append(dest.Contacts, value)
+	}
+	// Backup is mapped at runtime, no generated route from []Address to []*AddressDTO
+	if gomapper.HasRoute[[]Address, []*AddressDTO]() {
+		if err := gomapper.Map(source.Backup, &dest.Backup); err != nil {
+			return err
+		}
+	}
+	dest.Meta = source.Meta
+	// Score is mapped at runtime, no generated route from float64 to int
+	if gomapper.HasRoute[float64, int]() {
+		if err := gomapper.Map(source.Score, &dest.Score); err != nil {
+			return err
+		}
+	}
+	// Nickname is mapped at runtime, no generated route from sql.NullString to *string
+	if gomapper.HasRoute[sql.NullString, *string]() {
+		if err := gomapper.Map(source.Nickname, &dest.Nickname); err != nil {
+			return err
+		}
+	}
+	// Reviewed is mapped at runtime, no generated route from *Audit to *AuditDTO
+	if source.Reviewed != nil {
+		if gomapper.HasRoute[*Audit, *AuditDTO]() {
+			if err := gomapper.Map(source.Reviewed, &dest.Reviewed); err != nil {
+				return err
+			}
+		} else if gomapper.HasRoute[*Audit, AuditDTO]() {
+			dest.Reviewed = new(AuditDTO)
+			if err := gomapper.Map(source.Reviewed, dest.Reviewed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mapAddressToAddressDTO maps Address to AddressDTO.
+func mapAddressToAddressDTO(source Address, dest *AddressDTO) error {
+	dest.City = source.City
+	dest.Street = source.Street
+	return nil
+}
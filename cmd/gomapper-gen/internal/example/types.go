@@ -0,0 +1,65 @@
+// Package example contains types mapped with the code generated by gomapper-gen.
+package example
+
+import (
+	"database/sql"
+	"time"
+)
+
+//go:generate go -C ../.. run . -dir internal/example
+
+//gomapper:route User -> UserDTO
+//gomapper:route Address -> AddressDTO
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type AddressDTO struct {
+	City   string
+	Street string
+}
+
+type Audit struct {
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+type AuditDTO struct {
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+type User struct {
+	ID       int
+	Name     string
+	Tags     []string
+	Audit    Audit
+	Address  Address
+	Billing  *Address
+	Previous []Address
+	Contacts []*Address
+	Backup   []Address
+	Meta     map[string]string
+	Score    float64
+	Nickname sql.NullString
+	Reviewed *Audit
+}
+
+type UserDTO struct {
+	ID             int
+	Name           string
+	Tags           []string
+	AuditCreatedAt time.Time
+	AuditCreatedBy string
+	Address        AddressDTO
+	Billing        *AddressDTO
+	Previous       []AddressDTO
+	Contacts       []*AddressDTO
+	Backup         []*AddressDTO
+	Meta           map[string]string
+	Score          int
+	Nickname       *string
+	Reviewed       *AuditDTO
+}
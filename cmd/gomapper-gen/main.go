@@ -0,0 +1,47 @@
+// Command gomapper-gen generates mapping functions for routes declared with directives
+// in comments of the package files:
+//
+//	//gomapper:route User -> UserDTO
+//	//gomapper:route User -> dto.User
+//
+// Fields are matched with the same rules as gomapper.AutoRoute: by the same path or by the flattened path,
+// i.e. Address.City source field is mapped to AddressCity destination field. Fields of different types are
+// mapped with other generated routes, including pointers and slices of the routed types. Fields without
+// a generated route between their types are mapped with gomapper.Map, so converters, wrappers and other routes
+// registered at runtime are used like by AutoRoute. Type errors of the package are reported, the generation fails
+// when fields of routed types can't be resolved.
+//
+// The generated file contains plain Go mapping functions and the function registering them with
+// gomapper.AddRoute. The command is the separate module, so it's run with the version:
+//
+//	//go:generate go run github.com/insei/gomapper/cmd/gomapper-gen@latest
+//
+// or added to the module with go get github.com/insei/gomapper/cmd/gomapper-gen and run without the version.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package with //gomapper:route directives")
+	output := flag.String("output", "gomapper_gen.go", "name of the generated file in the package directory")
+	funcName := flag.String("func", "AddGeneratedRoutes", "name of the generated function registering routes")
+	flag.Parse()
+
+	src, warnings, err := generate(*dir, *output, *funcName)
+	for _, w := range warnings {
+		_, _ = fmt.Fprintln(os.Stderr, "gomapper-gen: warning:", w)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "gomapper-gen:", err)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(filepath.Join(*dir, *output), src, 0o644); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "gomapper-gen:", err)
+		os.Exit(1)
+	}
+}
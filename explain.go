@@ -0,0 +1,181 @@
+package gomapper
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// FieldMapping describes how the destination field is mapped by the auto route.
+type FieldMapping int
+
+const (
+	// FieldMappingUnmapped is the destination field without a matching source field.
+	FieldMappingUnmapped FieldMapping = iota
+	// FieldMappingDirect is the destination field copied directly from the source field of the same type.
+	FieldMappingDirect
+	// FieldMappingRoute is the destination field mapped with the registered route.
+	FieldMappingRoute
+	// FieldMappingConverter is the destination field mapped with the registered converter.
+	FieldMappingConverter
+	// FieldMappingNested is the struct destination field, which nested fields are mapped by their own paths.
+	FieldMappingNested
+	// FieldMappingTypeMismatch is the destination field matched with the source field of another type,
+	// the field is not mapped, because there is no route or converter between these types.
+	FieldMappingTypeMismatch
+	// FieldMappingResolver is the destination field set with WithFieldResolver.
+	FieldMappingResolver
+	// FieldMappingSkipped is the destination field skipped with WithDestFieldSkip or matched with
+	// the source field skipped with WithFieldSkip.
+	FieldMappingSkipped
+	// FieldMappingPointer is the pointer destination field allocated and mapped with the route between pointed types.
+	FieldMappingPointer
+)
+
+func (m FieldMapping) String() string {
+	switch m {
+	case FieldMappingUnmapped:
+		return "unmapped"
+	case FieldMappingDirect:
+		return "direct"
+	case FieldMappingRoute:
+		return "route"
+	case FieldMappingConverter:
+		return "converter"
+	case FieldMappingNested:
+		return "nested"
+	case FieldMappingTypeMismatch:
+		return "type mismatch"
+	case FieldMappingResolver:
+		return "resolver"
+	case FieldMappingSkipped:
+		return "skipped"
+	case FieldMappingPointer:
+		return "pointer"
+	default:
+		return "FieldMapping(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// FieldExplanation describes the mapping of the destination field.
+type FieldExplanation struct {
+	DestPath string
+	// SourcePath is the path of the source field, empty for resolved and unmapped fields.
+	SourcePath string
+	Mapping    FieldMapping
+	Match      FieldMatch
+	// Conditional is true when the field is mapped only when conditions are met.
+	Conditional bool
+	// Default is true when the field has the default value.
+	Default bool
+}
+
+// RouteExplanation is the mapping plan of the auto route.
+type RouteExplanation struct {
+	Source reflect.Type
+	Dest   reflect.Type
+	// Fields describes all destination fields in the order of the destination struct definition.
+	Fields []FieldExplanation
+	// NotInverted are options of the forward route, which aren't applied to this reverse route.
+	NotInverted []string
+}
+
+// String returns the mapping plan as a table.
+func (e *RouteExplanation) String() string {
+	b := &strings.Builder{}
+	_, _ = fmt.Fprintf(b, "%s -> %s\n", getTypeNameRecursive(e.Source, ""), getTypeNameRecursive(e.Dest, ""))
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "DESTINATION\tSOURCE\tMAPPING\tNOTES")
+	for _, f := range e.Fields {
+		var notes []string
+		if f.SourcePath != "" && f.Match != FieldMatchName {
+			notes = append(notes, f.Match.String())
+		}
+		if f.Conditional {
+			notes = append(notes, "conditional")
+		}
+		if f.Default {
+			notes = append(notes, "default")
+		}
+		source := f.SourcePath
+		if source == "" {
+			source = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.DestPath, source, f.Mapping, strings.Join(notes, ", "))
+	}
+	_ = w.Flush()
+	if len(e.NotInverted) > 0 {
+		_, _ = fmt.Fprintf(b, "not inverted: %s\n", strings.Join(e.NotInverted, ", "))
+	}
+	return b.String()
+}
+
+// ExplainRoute returns the mapping plan of the auto route from TSource to TDest.
+// Routes and converters used for fields are resolved at the moment of the call, like during mapping.
+func ExplainRoute[TSource, TDest any]() (*RouteExplanation, error) {
+	sourceType := reflect.TypeOf((*TSource)(nil)).Elem()
+	destType := reflect.TypeOf((*TDest)(nil)).Elem()
+	r, ok := routes[sourceType][reflect.PointerTo(destType)]
+	if !ok {
+		return nil, fmt.Errorf("route not found for type %s to type %s",
+			getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destType, ""))
+	}
+	if r.auto == nil {
+		return nil, fmt.Errorf("route %s -> %s is a %s route, only auto routes can be explained",
+			getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destType, ""), r.info.Kind)
+	}
+	explanation := &RouteExplanation{Source: sourceType, Dest: destType, NotInverted: r.info.NotInverted}
+	for _, destPath := range r.auto.destStorage.GetAllPaths() {
+		explanation.Fields = append(explanation.Fields, r.auto.explainField(destPath))
+	}
+	return explanation, nil
+}
+
+func (r *autoRoute) explainField(destPath string) FieldExplanation {
+	explanation := FieldExplanation{DestPath: destPath}
+	for _, d := range r.defaults {
+		if d.field.path == destPath {
+			explanation.Default = true
+		}
+	}
+	for _, res := range r.opts.Resolvers {
+		if res.field.matches(destPath) {
+			explanation.Mapping = FieldMappingResolver
+			return explanation
+		}
+	}
+	for _, path := range r.skippedDest {
+		if path == destPath {
+			explanation.Mapping = FieldMappingSkipped
+			return explanation
+		}
+	}
+	for _, f := range r.fields {
+		if f.dest.GetStructPath() != destPath {
+			continue
+		}
+		explanation.SourcePath = f.source.GetStructPath()
+		explanation.Match = f.match
+		explanation.Conditional = len(f.conditions) > 0
+		explanation.Mapping, _, _ = getFieldMapping(f.source, f.dest)
+		return explanation
+	}
+	for _, f := range r.fields {
+		if f.match == FieldMatchBase && strings.HasPrefix(destPath, f.dest.GetStructPath()+".") {
+			explanation.SourcePath = f.source.GetStructPath()
+			explanation.Match = FieldMatchBase
+			explanation.Mapping = FieldMappingRoute
+			return explanation
+		}
+	}
+	for _, path := range r.skippedSource {
+		if path == destPath || strings.ReplaceAll(path, ".", "") == destPath {
+			explanation.SourcePath = path
+			explanation.Mapping = FieldMappingSkipped
+			return explanation
+		}
+	}
+	return explanation
+}
@@ -0,0 +1,119 @@
+package gomapper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ExplainNestedSource struct {
+	Value string
+}
+
+type ExplainNestedDest struct {
+	Value string
+}
+
+type ExplainSource struct {
+	ID        int
+	Name      string
+	Password  string
+	Timeout   time.Duration
+	Count     int
+	Nested    ExplainNestedSource
+	Same      ReverseAddress
+	Address   ReverseAddress
+	Secret    string
+	Condition bool
+}
+
+type ExplainDest struct {
+	ID          int
+	FullName    string
+	Password    string
+	Timeout     string
+	Count       int64
+	Nested      ExplainNestedDest
+	Same        ReverseAddress
+	AddressCity string
+	Secret      string
+	Computed    string
+	Status      string `default:"new"`
+	Missing     string
+}
+
+func TestExplainRoute(t *testing.T) {
+	_ = AddConverter(func(source time.Duration) (string, error) {
+		return source.String(), nil
+	})
+	_ = AddRoute[ExplainNestedSource, ExplainNestedDest](func(source ExplainNestedSource, dest *ExplainNestedDest) error {
+		dest.Value = source.Value
+		return nil
+	})
+	err := AutoRoute[ExplainSource, ExplainDest](
+		WithFieldRename(func(source *ExplainSource) any {
+			return &source.Name
+		}, func(dest *ExplainDest) any {
+			return &dest.FullName
+		}),
+		WithFieldSkip(func(source *ExplainSource) any {
+			return &source.Secret
+		}),
+		WithDestFieldSkip(func(dest *ExplainDest) any {
+			return &dest.Password
+		}),
+		WithFieldResolver(func(dest *ExplainDest) *string {
+			return &dest.Computed
+		}, func(source ExplainSource) (string, error) {
+			return source.Name, nil
+		}),
+		WithCondition(func(source *ExplainSource) any {
+			return &source.ID
+		}, func(source ExplainSource) bool {
+			return source.Condition
+		}),
+	)
+	assert.NoError(t, err)
+
+	explanation, err := ExplainRoute[ExplainSource, ExplainDest]()
+	assert.NoError(t, err)
+	fields := map[string]FieldExplanation{}
+	for _, f := range explanation.Fields {
+		fields[f.DestPath] = f
+	}
+	assert.Equal(t, FieldExplanation{DestPath: "ID", SourcePath: "ID", Mapping: FieldMappingDirect, Conditional: true}, fields["ID"])
+	assert.Equal(t, FieldExplanation{DestPath: "FullName", SourcePath: "Name", Mapping: FieldMappingDirect, Match: FieldMatchRename}, fields["FullName"])
+	assert.Equal(t, FieldMappingSkipped, fields["Password"].Mapping)
+	assert.Equal(t, FieldExplanation{DestPath: "Timeout", SourcePath: "Timeout", Mapping: FieldMappingConverter}, fields["Timeout"])
+	assert.Equal(t, FieldMappingTypeMismatch, fields["Count"].Mapping)
+	assert.Equal(t, FieldMappingRoute, fields["Nested"].Mapping)
+	assert.Equal(t, FieldMappingDirect, fields["Nested.Value"].Mapping)
+	assert.Equal(t, FieldMappingNested, fields["Same"].Mapping)
+	assert.Equal(t, FieldExplanation{DestPath: "AddressCity", SourcePath: "Address.City", Mapping: FieldMappingDirect, Match: FieldMatchFlatten}, fields["AddressCity"])
+	assert.Equal(t, FieldExplanation{DestPath: "Secret", SourcePath: "Secret", Mapping: FieldMappingSkipped}, fields["Secret"])
+	assert.Equal(t, FieldMappingResolver, fields["Computed"].Mapping)
+	assert.Equal(t, FieldExplanation{DestPath: "Status", Mapping: FieldMappingUnmapped, Default: true}, fields["Status"])
+	assert.Equal(t, FieldExplanation{DestPath: "Missing", Mapping: FieldMappingUnmapped}, fields["Missing"])
+
+	report := explanation.String()
+	assert.Contains(t, report, "github.com/insei/gomapper.ExplainSource -> github.com/insei/gomapper.ExplainDest")
+	for _, line := range strings.Split(report, "\n") {
+		if strings.HasPrefix(line, "AddressCity ") {
+			assert.Equal(t, []string{"AddressCity", "Address.City", "direct", "flatten"}, strings.Fields(line))
+		}
+		if strings.HasPrefix(line, "Missing ") {
+			assert.Equal(t, []string{"Missing", "-", "unmapped"}, strings.Fields(line))
+		}
+	}
+
+	t.Run("Manual route", func(t *testing.T) {
+		_, err := ExplainRoute[ExplainNestedSource, ExplainNestedDest]()
+		assert.Error(t, err)
+	})
+	t.Run("Route not found", func(t *testing.T) {
+		_, err := ExplainRoute[ExplainDest, ExplainSource]()
+		assert.Error(t, err)
+	})
+}
@@ -1,10 +1,311 @@
 package gomapper
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
 )
 
+// defaultMaxDepth is the maximum depth of nested routes, which protects from the infinite recursion
+// on cyclic references and deeply nested inputs.
+const defaultMaxDepth = 1000
+
+// config is the global configuration of the mapper.
+type config struct {
+	referenceTracking bool
+	recoverPanics     bool
+	depth             depthLimit
+}
+
+// depthLimit is the limit of the depth of fields mapped with nested routes.
+type depthLimit struct {
+	max int
+	// truncate leaves fields past the limit zero instead of returning MaxDepthError
+	truncate bool
+}
+
+var globalConfig = newConfig()
+
+func newConfig() config {
+	return config{depth: depthLimit{max: defaultMaxDepth}}
+}
+
+// Configure replaces the global configuration of the mapper, options which are not passed are reset to defaults.
+// Configure is not safe for concurrent use with Map and should be called on startup.
+func Configure(opts ...ConfigOption) {
+	cfg := newConfig()
+	for _, o := range opts {
+		o.applyConfig(&cfg)
+	}
+	globalConfig = cfg
+}
+
+// MaxDepthError is returned when the field is mapped with nested routes deeper than the max depth.
+type MaxDepthError struct {
+	MaxDepth int
+	// Path is the path of the source field exceeding the max depth, i.e. Children[0].Parent.
+	Path string
+}
+
+// maxDepthErrorSegments is the number of first and last segments of the path kept in the text of MaxDepthError.
+const maxDepthErrorSegments = 5
+
+func (e *MaxDepthError) Error() string {
+	return fmt.Sprintf("max depth %d exceeded at field %s", e.MaxDepth, shortenPath(e.Path, maxDepthErrorSegments))
+}
+
+// shortenPath keeps n first and n last segments of the path, other segments are replaced with their count,
+// so paths of cyclic references don't repeat the same segments thousands of times.
+func shortenPath(path string, n int) string {
+	segments := strings.Split(path, ".")
+	if len(segments) <= 2*n+1 {
+		return path
+	}
+	return fmt.Sprintf("%s.(%d more).%s", strings.Join(segments[:n], "."), len(segments)-2*n,
+		strings.Join(segments[len(segments)-n:], "."))
+}
+
+// PanicError is returned instead of the panic in the mapping function, hook, resolver or condition of the route,
+// when the panic recovery is enabled.
+type PanicError struct {
+	// Source and Dest are types of the route, which panicked.
+	Source reflect.Type
+	Dest   reflect.Type
+	// Path is the path of the source field being mapped, i.e. Items[2].Address, empty for the source passed to Map.
+	Path string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	route := fmt.Sprintf("%s -> %s", getTypeNameRecursive(e.Source, ""), getTypeNameRecursive(e.Dest, ""))
+	if e.Path == "" {
+		return fmt.Sprintf("panic in route %s: %v", route, e.Value)
+	}
+	return fmt.Sprintf("panic in route %s at field %s: %v", route, e.Path, e.Value)
+}
+
+// mapState is the state of the single Map call shared by nested routes.
+type mapState struct {
+	depth int
+	limit depthLimit
+	// path are segments of the path of the source field being mapped
+	path []string
+	// refs are destinations mapped from source pointers, when the reference tracking is enabled
+	refs map[refKey]reflect.Value
+	// recoverPanics converts panics in routes to PanicError
+	recoverPanics bool
+	// parallelism is the number of workers mapping elements of slices, elements are mapped sequentially when it's 0
+	parallelism int
+}
+
+type refKey struct {
+	source any
+	dest   reflect.Type
+}
+
+func newMapState() *mapState {
+	s := &mapState{}
+	s.reset()
+	return s
+}
+
+// reset prepares the state for the new Map call, buffers of the state are reused.
+func (s *mapState) reset() {
+	s.depth = 0
+	s.limit = globalConfig.depth
+	s.path = s.path[:0]
+	s.recoverPanics = globalConfig.recoverPanics
+	s.parallelism = 0
+	switch {
+	case !globalConfig.referenceTracking:
+		s.refs = nil
+	case s.refs == nil:
+		s.refs = map[refKey]reflect.Value{}
+	default:
+		clear(s.refs)
+	}
+}
+
+// statePool reuses states of MapAs calls.
+var statePool = sync.Pool{New: func() any { return &mapState{} }}
+
+// fork returns the state for the worker mapping elements of the slice in parallel, the worker maps elements
+// sequentially and tracks references separately.
+func (s *mapState) fork() *mapState {
+	ws := &mapState{
+		depth:         s.depth,
+		limit:         s.limit,
+		path:          append([]string(nil), s.path...),
+		recoverPanics: s.recoverPanics,
+	}
+	if s.refs != nil {
+		// references tracked by the caller are shared by all workers, new ones are tracked by each worker
+		ws.refs = maps.Clone(s.refs)
+	}
+	return ws
+}
+
+// track remembers the destination pointer mapped from the source pointer.
+func (s *mapState) track(source any, destPtr reflect.Value) {
+	if s.refs == nil {
+		return
+	}
+	key := refKey{source: source, dest: destPtr.Type().Elem()}
+	if _, ok := s.refs[key]; !ok {
+		s.refs[key] = destPtr
+	}
+}
+
+// mapValue maps the source to the destination with the route found for their types.
+func (s *mapState) mapValue(source any, dest any) error {
+	if err := validateSource(source); err != nil {
+		return err
+	}
+	sourceForMap := prepareSource(source)
+	r, err := findRoute(reflect.TypeOf(source), reflect.TypeOf(dest))
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("route not found for type %s to type %s",
+			getTypeName(sourceForMap), getTypeName(dest))
+	}
+	if r.info.Source == reflect.TypeOf(source) || r.info.Source.Kind() == reflect.Interface {
+		return r.mapFunc(s, source, dest)
+	}
+	return r.mapFunc(s, sourceForMap, dest)
+}
+
+// mapPointer returns the new destination pointer of destType mapped from the source pointer,
+// with the reference tracking the source pointer mapped before returns the same destination pointer.
+func (s *mapState) mapPointer(source any, destType reflect.Type) (reflect.Value, error) {
+	if s.refs != nil {
+		if destPtr, ok := s.refs[refKey{source: source, dest: destType}]; ok {
+			return destPtr, nil
+		}
+	}
+	destPtr := reflect.New(destType)
+	// the pointer is tracked before mapping, so cyclic references resolve to it
+	s.track(source, destPtr)
+	if err := s.mapValue(source, destPtr.Interface()); err != nil {
+		if s.refs != nil {
+			delete(s.refs, refKey{source: source, dest: destType})
+		}
+		return reflect.Value{}, err
+	}
+	return destPtr, nil
+}
+
+// mapPointerTo is the typed mapPointer.
+func mapPointerTo[TDest any](s *mapState, source any) (*TDest, error) {
+	destPtr, err := s.mapPointer(source, reflect.TypeOf((*TDest)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return destPtr.Interface().(*TDest), nil
+}
+
+// recoverPanic converts the panic in the route to PanicError, it must be deferred by the route func.
+func (s *mapState) recoverPanic(r *route, err *error) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	*err = &PanicError{
+		Source: r.info.Source,
+		Dest:   r.info.Dest,
+		Path:   s.fieldPath(),
+		Value:  p,
+		Stack:  debug.Stack(),
+	}
+}
+
+// errDepthTruncated is returned from nested routes past the depth limit, when the depth limit truncates mapping.
+var errDepthTruncated = errors.New("depth limit reached")
+
+// enter increases the depth of nested auto routes, it returns an error when the depth limit is exceeded.
+func (s *mapState) enter() error {
+	if s.depth > s.limit.max {
+		if s.limit.truncate {
+			return errDepthTruncated
+		}
+		return &MaxDepthError{MaxDepth: s.limit.max, Path: s.fieldPath()}
+	}
+	s.depth++
+	return nil
+}
+
+func (s *mapState) exit() {
+	s.depth--
+}
+
+// limitDepth applies the depth limit of the route relative to the current depth, if it's stricter
+// than the current limit, returned func restores the previous limit.
+func (s *mapState) limitDepth(limit depthLimit) func() {
+	previous := s.limit
+	if s.depth+limit.max < previous.max {
+		s.limit = depthLimit{max: s.depth + limit.max, truncate: limit.truncate}
+	}
+	return func() {
+		s.limit = previous
+	}
+}
+
+// mapField runs fn mapping the field with nested routes, the field is left zero when nested routes are truncated
+// by the depth limit.
+func (s *mapState) mapField(path string, destPtr any, fn func() error) error {
+	s.pushPath(path)
+	defer s.popPath()
+	err := fn()
+	if errors.Is(err, errDepthTruncated) {
+		reflect.ValueOf(destPtr).Elem().SetZero()
+		return nil
+	}
+	return err
+}
+
+// mapElement runs fn mapping the slice element with the index, the element is left zero when nested routes
+// are truncated by the depth limit, so the destination slice keeps the length of the source slice.
+func (s *mapState) mapElement(i int, destPtr any, fn func() error) error {
+	s.pushPath("[" + strconv.Itoa(i) + "]")
+	defer s.popPath()
+	err := fn()
+	if errors.Is(err, errDepthTruncated) {
+		reflect.ValueOf(destPtr).Elem().SetZero()
+		return nil
+	}
+	return err
+}
+
+func (s *mapState) pushPath(segment string) {
+	s.path = append(s.path, segment)
+}
+
+func (s *mapState) popPath() {
+	s.path = s.path[:len(s.path)-1]
+}
+
+// fieldPath joins path segments, indexes of slice elements are joined without dots.
+func (s *mapState) fieldPath() string {
+	b := strings.Builder{}
+	for _, segment := range s.path {
+		if b.Len() > 0 && !strings.HasPrefix(segment, "[") {
+			b.WriteString(".")
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
 func validateSource(source any) error {
 	sourceTypeName := getTypeName(source)
 	if source == nil {
@@ -14,6 +315,9 @@ func validateSource(source any) error {
 	if typeOf.Kind() == reflect.Ptr && typeOf.Elem().Kind() == reflect.Ptr {
 		return fmt.Errorf("source can have a pointer type, but not a pointer to pointer, source type: %s", sourceTypeName)
 	}
+	if typeOf.Kind() == reflect.Ptr && reflect.ValueOf(source).IsNil() {
+		return fmt.Errorf("source value can't be nil, source type: %s", sourceTypeName)
+	}
 	return nil
 }
 
@@ -35,6 +339,9 @@ func getTypeNameRecursive(target reflect.Type, typeName string) string {
 		}
 		return getTypeNameRecursive(newTarget, newTypeName)
 	}
+	if target.PkgPath() == "" {
+		return typeName + target.String()
+	}
 	return fmt.Sprintf("%s%s.%s", typeName, target.PkgPath(), target.Name())
 }
 
@@ -46,7 +353,9 @@ func getTypeName(target any) string {
 	return "undefined"
 }
 
-func validateDest(dest any) error {
+// validateDest checks the destination, pointers to pointers are valid only with the route from the source to the
+// pointer type, like the built-in route from the wrapper to the pointer to its value.
+func validateDest(source, dest any) error {
 	dValueOf := reflect.ValueOf(dest)
 	dTypeName := getTypeName(dest)
 	if dValueOf.Kind() != reflect.Ptr {
@@ -56,6 +365,9 @@ func validateDest(dest any) error {
 		return fmt.Errorf("destenation value can't be nil, destenation type: %s", dTypeName)
 	}
 	if dValueOf.Kind() == reflect.Ptr && dValueOf.Elem().Kind() == reflect.Ptr {
+		if r, _ := findRoute(reflect.TypeOf(source), dValueOf.Type()); r != nil {
+			return nil
+		}
 		return fmt.Errorf("destenation value should have a pointer type, not a pointer to pointer, but has %s type", dTypeName)
 	}
 	return nil
@@ -67,22 +379,93 @@ func Map(source interface{}, dest interface{}) error {
 	if err != nil {
 		return err
 	}
-	sourceForMap := prepareSource(source)
-	err = validateDest(dest)
+	err = validateDest(source, dest)
 	if err != nil {
 		return err
 	}
-	route, ok := routes[reflect.TypeOf(sourceForMap)]
-	if !ok {
-		return fmt.Errorf("route not found for type %s to type %s",
-			getTypeName(sourceForMap), getTypeName(dest))
+	state := newMapState()
+	if reflect.TypeOf(source).Kind() == reflect.Ptr {
+		state.track(source, reflect.ValueOf(dest))
 	}
-	mapFunc, ok := route[reflect.TypeOf(dest)]
-	if !ok {
-		return fmt.Errorf("route not found for type %s to type %s",
-			getTypeName(sourceForMap), getTypeName(dest))
+	return state.mapValue(source, dest)
+}
+
+// MapParallel maps source to dest like Map, but elements of slices are mapped by the pool of workers,
+// see WithParallelism. The order of elements is preserved, mapping stops at the first failed element and its error
+// is returned like by Map, panics of workers are raised again with the stack of the worker. Elements are mapped
+// sequentially inside workers, references tracked before elements are shared, new ones are tracked by each worker.
+func MapParallel(source interface{}, dest interface{}, opts ...MapOption) error {
+	err := validateSource(source)
+	if err != nil {
+		return err
+	}
+	err = validateDest(source, dest)
+	if err != nil {
+		return err
+	}
+	opt := &mapOptions{parallelism: runtime.GOMAXPROCS(0)}
+	for _, o := range opts {
+		o.applyMap(opt)
+	}
+	state := newMapState()
+	state.parallelism = opt.parallelism
+	if reflect.TypeOf(source).Kind() == reflect.Ptr {
+		state.track(source, reflect.ValueOf(dest))
+	}
+	return state.mapValue(source, dest)
+}
+
+// typedRoute is the route resolved by MapAs for TSource and TDest.
+type typedRoute[TSource, TDest any] struct {
+	route *route
+	// mapFunc is nil, when there is no route registered exactly from TSource to TDest
+	mapFunc     func(s *mapState, source TSource, dest *TDest) error
+	isSourcePtr bool
+}
+
+// typedRoutes are routes resolved by MapAs, keyed by the nil *typedRoute of the instantiation.
+// The cache is cleared when routes are registered or removed.
+var typedRoutes sync.Map
+
+func getTypedRoute[TSource, TDest any]() *typedRoute[TSource, TDest] {
+	key := (*typedRoute[TSource, TDest])(nil)
+	if cached, ok := typedRoutes.Load(key); ok {
+		return cached.(*typedRoute[TSource, TDest])
+	}
+	sourceType := reflect.TypeOf((*TSource)(nil)).Elem()
+	tr := &typedRoute[TSource, TDest]{isSourcePtr: sourceType.Kind() == reflect.Ptr}
+	if r, ok := routes[sourceType][reflect.TypeOf((*TDest)(nil))]; ok {
+		tr.route = r
+		tr.mapFunc, _ = r.typedMapFunc.(func(s *mapState, source TSource, dest *TDest) error)
+	}
+	typedRoutes.Store(key, tr)
+	return tr
+}
+
+// MapAs maps the source to the new dest object like MapTo, but the route registered from TSource to TDest
+// is called directly without reflection, the destination is the only allocation of the mapper, allocations
+// of the route itself remain. When there is no route registered exactly from TSource to TDest, MapAs falls back
+// to MapTo. The pointer source is tracked like by Map, when the reference tracking is enabled.
+func MapAs[TSource, TDest any](source TSource) (dest TDest, err error) {
+	tr := getTypedRoute[TSource, TDest]()
+	if tr.mapFunc == nil {
+		return MapTo[TDest](source)
+	}
+	if tr.isSourcePtr && reflect.ValueOf(source).IsNil() {
+		return dest, validateSource(source)
+	}
+	s := statePool.Get().(*mapState)
+	s.reset()
+	defer statePool.Put(s)
+	destPtr := new(TDest)
+	if tr.isSourcePtr {
+		s.track(source, reflect.ValueOf(destPtr))
+	}
+	if s.recoverPanics {
+		defer s.recoverPanic(tr.route, &err)
 	}
-	return mapFunc(sourceForMap, dest)
+	err = tr.mapFunc(s, source, destPtr)
+	return *destPtr, err
 }
 
 // MapTo Map source to the new dest object
@@ -1,6 +1,11 @@
 package gomapper
 
 import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,8 +25,65 @@ var converterFunc = func(source TestingStructSource, dest *TestingStructDest) er
 }
 
 func TestAddRoute(t *testing.T) {
+	RemoveRoute[TestingStructSource, TestingStructDest]()
 	err := AddRoute[TestingStructSource, TestingStructDest](converterFunc)
 	assert.NoError(t, err)
+	t.Run("Duplicate route", func(t *testing.T) {
+		err := AddRoute[TestingStructSource, TestingStructDest](converterFunc)
+		assert.Error(t, err)
+	})
+	t.Run("Replace route", func(t *testing.T) {
+		err := AddRoute[TestingStructSource, TestingStructDest](func(source TestingStructSource, dest *TestingStructDest) error {
+			dest.Name = source.Name + "Replaced"
+			return nil
+		}, WithReplace())
+		assert.NoError(t, err)
+		dest, err := MapTo[TestingStructDest](TestingStructSource{Name: "Test1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1Replaced", dest.Name)
+		slice, err := MapTo[[]TestingStructDest]([]TestingStructSource{{Name: "Test1"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1Replaced", slice[0].Name)
+		err = AddRoute[TestingStructSource, TestingStructDest](converterFunc, WithReplace())
+		assert.NoError(t, err)
+	})
+}
+
+type RemoveRouteSource struct {
+	Name string
+}
+
+type RemoveRouteDest struct {
+	Name string
+}
+
+func TestRemoveRoute(t *testing.T) {
+	err := AutoRoute[RemoveRouteSource, RemoveRouteDest]()
+	assert.NoError(t, err)
+	sliceRouteFunc := func(source []RemoveRouteSource, dest *[]RemoveRouteDest) error {
+		*dest = make([]RemoveRouteDest, len(source))
+		return nil
+	}
+	err = AddRoute[[]RemoveRouteSource, []RemoveRouteDest](sliceRouteFunc)
+	assert.NoError(t, err)
+
+	assert.True(t, RemoveRoute[RemoveRouteSource, RemoveRouteDest]())
+	assert.False(t, HasRoute[RemoveRouteSource, RemoveRouteDest]())
+	assert.False(t, HasRoute[[]RemoveRouteSource, []*RemoveRouteDest]())
+	assert.False(t, HasRoute[[]*RemoveRouteSource, []RemoveRouteDest]())
+	assert.False(t, HasRoute[[]*RemoveRouteSource, []*RemoveRouteDest]())
+	// explicitly registered route between slices is not removed
+	assert.True(t, HasRoute[[]RemoveRouteSource, []RemoveRouteDest]())
+	assert.False(t, RemoveRoute[RemoveRouteSource, RemoveRouteDest]())
+
+	_, err = MapTo[RemoveRouteDest](RemoveRouteSource{Name: "Test1"})
+	assert.Error(t, err)
+	err = AutoRoute[RemoveRouteSource, RemoveRouteDest]()
+	assert.NoError(t, err)
+	dest, err := MapTo[[]RemoveRouteDest]([]RemoveRouteSource{{Name: "Test1"}})
+	assert.NoError(t, err)
+	// generated slice route doesn't replace explicitly registered route
+	assert.Equal(t, []RemoveRouteDest{{}}, dest)
 }
 
 func TestMapTo(t *testing.T) {
@@ -281,3 +343,707 @@ func TestMap(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+type Named interface {
+	GetName() string
+}
+
+type Titled interface {
+	Named
+	GetTitle() string
+}
+
+type Coded interface {
+	GetCode() string
+}
+
+type NamedValue struct {
+	Name string
+}
+
+func (v NamedValue) GetName() string {
+	return v.Name
+}
+
+type NamedPointer struct {
+	Name string
+}
+
+func (v *NamedPointer) GetName() string {
+	return v.Name
+}
+
+type TitledValue struct {
+	Name  string
+	Title string
+}
+
+func (v TitledValue) GetName() string {
+	return v.Name
+}
+
+func (v TitledValue) GetTitle() string {
+	return v.Title
+}
+
+type CodedNamedValue struct {
+	Name string
+	Code string
+}
+
+func (v CodedNamedValue) GetName() string {
+	return v.Name
+}
+
+func (v CodedNamedValue) GetCode() string {
+	return v.Code
+}
+
+type ConcreteNamedValue struct {
+	Name string
+}
+
+func (v ConcreteNamedValue) GetName() string {
+	return v.Name
+}
+
+type InterfaceDest struct {
+	Name string
+}
+
+type InterfaceHolderSource struct {
+	Named Named
+}
+
+type InterfaceHolderDest struct {
+	Named InterfaceDest
+}
+
+type InterfaceSliceSource struct {
+	Items []NamedValue
+}
+
+type InterfaceSliceDest struct {
+	Items []InterfaceDest
+}
+
+func TestMapInterfaceRoute(t *testing.T) {
+	err := AddRoute[Named, InterfaceDest](func(source Named, dest *InterfaceDest) error {
+		dest.Name = source.GetName()
+		return nil
+	})
+	assert.NoError(t, err)
+	err = AddRoute[Titled, InterfaceDest](func(source Titled, dest *InterfaceDest) error {
+		dest.Name = source.GetTitle() + " " + source.GetName()
+		return nil
+	})
+	assert.NoError(t, err)
+	err = AddRoute[Coded, InterfaceDest](func(source Coded, dest *InterfaceDest) error {
+		dest.Name = source.GetCode()
+		return nil
+	})
+	assert.NoError(t, err)
+	err = AddRoute[ConcreteNamedValue, InterfaceDest](func(source ConcreteNamedValue, dest *InterfaceDest) error {
+		dest.Name = "Concrete " + source.Name
+		return nil
+	})
+	assert.NoError(t, err)
+
+	t.Run("Source implements interface", func(t *testing.T) {
+		dest, err := MapTo[InterfaceDest](NamedValue{Name: "Test1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1", dest.Name)
+		dest, err = MapTo[InterfaceDest](&NamedValue{Name: "Test2"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test2", dest.Name)
+	})
+	t.Run("Source implements interface with pointer receiver", func(t *testing.T) {
+		dest, err := MapTo[InterfaceDest](&NamedPointer{Name: "Test1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1", dest.Name)
+		dest, err = MapTo[InterfaceDest](NamedPointer{Name: "Test2"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test2", dest.Name)
+	})
+	t.Run("Most specific interface is used", func(t *testing.T) {
+		dest, err := MapTo[InterfaceDest](TitledValue{Name: "Test1", Title: "Mr"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Mr Test1", dest.Name)
+	})
+	t.Run("Concrete route is preferred", func(t *testing.T) {
+		dest, err := MapTo[InterfaceDest](ConcreteNamedValue{Name: "Test1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Concrete Test1", dest.Name)
+	})
+	t.Run("Ambiguous interfaces", func(t *testing.T) {
+		_, err := MapTo[InterfaceDest](CodedNamedValue{Name: "Test1", Code: "Code1"})
+		assert.ErrorContains(t, err, "ambiguous")
+		assert.False(t, HasRoute[CodedNamedValue, InterfaceDest]())
+	})
+	t.Run("Slice of interfaces", func(t *testing.T) {
+		dest, err := MapTo[[]InterfaceDest]([]Named{NamedValue{Name: "Test1"}, &NamedPointer{Name: "Test2"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []InterfaceDest{{Name: "Test1"}, {Name: "Test2"}}, dest)
+	})
+	t.Run("Slice of implementations", func(t *testing.T) {
+		dest, err := MapTo[[]InterfaceDest]([]NamedValue{{Name: "Test1"}, {Name: "Test2"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []InterfaceDest{{Name: "Test1"}, {Name: "Test2"}}, dest)
+		pointers, err := MapTo[[]*InterfaceDest]([]*NamedPointer{{Name: "Test1"}, nil})
+		assert.NoError(t, err)
+		assert.Equal(t, []*InterfaceDest{{Name: "Test1"}, nil}, pointers)
+		assert.True(t, HasRoute[[]TitledValue, []InterfaceDest]())
+		_, err = MapTo[[]InterfaceDest]([]CodedNamedValue{{Name: "Test1"}})
+		assert.ErrorContains(t, err, "ambiguous")
+	})
+	t.Run("Auto route field of slice of implementations", func(t *testing.T) {
+		err := AutoRoute[InterfaceSliceSource, InterfaceSliceDest]()
+		assert.NoError(t, err)
+		dest, err := MapTo[InterfaceSliceDest](InterfaceSliceSource{Items: []NamedValue{{Name: "Test1"}}})
+		assert.NoError(t, err)
+		assert.Equal(t, []InterfaceDest{{Name: "Test1"}}, dest.Items)
+	})
+	t.Run("Auto route field of interface type", func(t *testing.T) {
+		err := AutoRoute[InterfaceHolderSource, InterfaceHolderDest]()
+		assert.NoError(t, err)
+		dest, err := MapTo[InterfaceHolderDest](InterfaceHolderSource{Named: &NamedPointer{Name: "Test1"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1", dest.Named.Name)
+		dest, err = MapTo[InterfaceHolderDest](InterfaceHolderSource{})
+		assert.NoError(t, err)
+		assert.Empty(t, dest.Named.Name)
+	})
+	assert.True(t, HasRoute[NamedValue, InterfaceDest]())
+	assert.True(t, HasRoute[*NamedPointer, InterfaceDest]())
+}
+
+type PointerRouteSource struct {
+	Name string
+}
+
+type PointerRouteDest struct {
+	Name string
+}
+
+func TestMapPointerSourceRoute(t *testing.T) {
+	err := AddRoute[*PointerRouteSource, PointerRouteDest](func(source *PointerRouteSource, dest *PointerRouteDest) error {
+		dest.Name = source.Name
+		source.Name = "Visited"
+		return nil
+	})
+	assert.NoError(t, err)
+	t.Run("Source is a pointer", func(t *testing.T) {
+		source := &PointerRouteSource{Name: "Test1"}
+		dest, err := MapTo[PointerRouteDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1", dest.Name)
+		assert.Equal(t, "Visited", source.Name)
+	})
+	t.Run("Source is a value", func(t *testing.T) {
+		source := PointerRouteSource{Name: "Test1"}
+		dest, err := MapTo[PointerRouteDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1", dest.Name)
+		assert.Equal(t, "Test1", source.Name)
+	})
+	t.Run("Source is a nil pointer", func(t *testing.T) {
+		_, err := MapTo[PointerRouteDest]((*PointerRouteSource)(nil))
+		assert.Error(t, err)
+	})
+	t.Run("Source is a pointer to pointer", func(t *testing.T) {
+		err := AddRoute[**PointerRouteSource, PointerRouteDest](func(source **PointerRouteSource, dest *PointerRouteDest) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
+
+type GraphNode struct {
+	Name     string
+	Parent   *GraphNode
+	Children []*GraphNode
+}
+
+type GraphNodeDTO struct {
+	Name     string
+	Parent   *GraphNodeDTO
+	Children []*GraphNodeDTO
+}
+
+type GraphPair struct {
+	First  *GraphNode
+	Second *GraphNode
+}
+
+type GraphPairDTO struct {
+	First  *GraphNodeDTO
+	Second *GraphNodeDTO
+}
+
+func TestMapReferenceTracking(t *testing.T) {
+	assert.NoError(t, AutoRoute[GraphNode, GraphNodeDTO]())
+	assert.NoError(t, AutoRoute[GraphPair, GraphPairDTO]())
+	newTree := func() *GraphNode {
+		root := &GraphNode{Name: "root"}
+		root.Children = []*GraphNode{{Name: "child1", Parent: root}, {Name: "child2", Parent: root}}
+		return root
+	}
+
+	t.Run("Pointer fields are mapped deeply", func(t *testing.T) {
+		node := &GraphNode{Name: "node"}
+		pair := GraphPair{First: node, Second: node}
+		dest, err := MapTo[GraphPairDTO](pair)
+		assert.NoError(t, err)
+		assert.Equal(t, "node", dest.First.Name)
+		assert.Equal(t, "node", dest.Second.Name)
+		assert.NotSame(t, dest.First, dest.Second)
+		dest, err = MapTo[GraphPairDTO](GraphPair{First: node})
+		assert.NoError(t, err)
+		assert.Nil(t, dest.Second)
+	})
+	t.Run("Max depth guards cycles", func(t *testing.T) {
+		_, err := MapTo[GraphNodeDTO](newTree())
+		assert.ErrorContains(t, err, "max depth")
+	})
+	t.Run("Cycles with reference tracking", func(t *testing.T) {
+		Configure(WithReferenceTracking())
+		defer Configure()
+		dest := &GraphNodeDTO{}
+		err := Map(newTree(), dest)
+		assert.NoError(t, err)
+		assert.Equal(t, "root", dest.Name)
+		assert.Len(t, dest.Children, 2)
+		for _, child := range dest.Children {
+			assert.Same(t, dest, child.Parent)
+		}
+		assert.Equal(t, "child2", dest.Children[1].Name)
+	})
+	t.Run("Shared references with reference tracking", func(t *testing.T) {
+		Configure(WithReferenceTracking())
+		defer Configure()
+		node := &GraphNode{Name: "node"}
+		dest, err := MapTo[GraphPairDTO](GraphPair{First: node, Second: node})
+		assert.NoError(t, err)
+		assert.Same(t, dest.First, dest.Second)
+		nodes, err := MapTo[[]*GraphNodeDTO]([]*GraphNode{node, nil, node})
+		assert.NoError(t, err)
+		assert.Nil(t, nodes[1])
+		assert.Same(t, nodes[0], nodes[2])
+	})
+}
+
+type DepthNode struct {
+	Name  string
+	Child *DepthNode
+	Items []*DepthNode
+}
+
+type DepthNodeDTO struct {
+	Name  string
+	Child *DepthNodeDTO
+	Items []*DepthNodeDTO
+}
+
+type DepthTree struct {
+	Root *DepthNode
+}
+
+type DepthTreeDTO struct {
+	Root *DepthNodeDTO
+}
+
+func TestMapMaxDepth(t *testing.T) {
+	assert.NoError(t, AutoRoute[DepthNode, DepthNodeDTO]())
+	chain := &DepthNode{Name: "1", Child: &DepthNode{Name: "2", Child: &DepthNode{Name: "3", Child: &DepthNode{Name: "4"}}}}
+
+	t.Run("Global max depth", func(t *testing.T) {
+		Configure(WithMaxDepth(2))
+		defer Configure()
+		_, err := MapTo[DepthNodeDTO](chain)
+		var maxDepthErr *MaxDepthError
+		assert.ErrorAs(t, err, &maxDepthErr)
+		assert.Equal(t, 2, maxDepthErr.MaxDepth)
+		assert.Equal(t, "Child.Child.Child", maxDepthErr.Path)
+
+		_, err = MapTo[DepthNodeDTO](DepthNode{Items: []*DepthNode{{}, {Child: chain}}})
+		assert.ErrorAs(t, err, &maxDepthErr)
+		assert.Equal(t, "Items[1].Child.Child", maxDepthErr.Path)
+
+		dest, err := MapTo[DepthNodeDTO](chain.Child)
+		assert.NoError(t, err)
+		assert.Equal(t, "4", dest.Child.Child.Name)
+	})
+	t.Run("Global depth limit", func(t *testing.T) {
+		Configure(WithDepthLimit(2))
+		defer Configure()
+		dest, err := MapTo[DepthNodeDTO](chain)
+		assert.NoError(t, err)
+		assert.Equal(t, "3", dest.Child.Child.Name)
+		assert.Nil(t, dest.Child.Child.Child)
+
+		items := &DepthNode{Name: "1", Child: &DepthNode{Name: "2", Child: &DepthNode{Name: "3", Items: []*DepthNode{{Name: "4"}, {Name: "5"}}}}}
+		dest, err = MapTo[DepthNodeDTO](items)
+		assert.NoError(t, err)
+		assert.Equal(t, []*DepthNodeDTO{nil, nil}, dest.Child.Child.Items)
+	})
+	t.Run("Auto route max depth", func(t *testing.T) {
+		err := AutoRoute[DepthTree, DepthTreeDTO](WithMaxDepth(1))
+		assert.NoError(t, err)
+		_, err = MapTo[DepthTreeDTO](DepthTree{Root: chain})
+		var maxDepthErr *MaxDepthError
+		assert.ErrorAs(t, err, &maxDepthErr)
+		assert.Equal(t, "Root.Child", maxDepthErr.Path)
+
+		err = AutoRoute[DepthTree, DepthTreeDTO](WithReplace(), WithDepthLimit(1))
+		assert.NoError(t, err)
+		dest, err := MapTo[DepthTreeDTO](DepthTree{Root: chain})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", dest.Root.Name)
+		assert.Nil(t, dest.Root.Child)
+	})
+	t.Run("Default max depth", func(t *testing.T) {
+		cycle := &DepthNode{Name: "1"}
+		cycle.Child = cycle
+		_, err := MapTo[DepthNodeDTO](cycle)
+		var maxDepthErr *MaxDepthError
+		assert.ErrorAs(t, err, &maxDepthErr)
+		assert.Equal(t, defaultMaxDepth+1, strings.Count(maxDepthErr.Path, "Child"))
+		assert.EqualError(t, err, "max depth 1000 exceeded at field Child.Child.Child.Child.Child.(991 more)."+
+			"Child.Child.Child.Child.Child")
+	})
+	t.Run("Negative max depth", func(t *testing.T) {
+		err := AutoRoute[DepthTree, DepthTreeDTO](WithReplace(), WithMaxDepth(-1))
+		assert.ErrorContains(t, err, "max depth can't be negative")
+	})
+}
+
+type PanicItem struct {
+	Name *string
+}
+
+type PanicItemDTO struct {
+	Name string
+}
+
+type PanicBatch struct {
+	Items []PanicItem
+}
+
+type PanicBatchDTO struct {
+	Items []PanicItemDTO
+}
+
+type PanicHookSource struct {
+	Name string
+}
+
+type PanicHookDest struct {
+	Name string
+}
+
+func TestMapPanicRecovery(t *testing.T) {
+	assert.NoError(t, AddRoute[PanicItem, PanicItemDTO](func(source PanicItem, dest *PanicItemDTO) error {
+		dest.Name = *source.Name
+		return nil
+	}))
+	assert.NoError(t, AutoRoute[PanicBatch, PanicBatchDTO]())
+	assert.NoError(t, AutoRoute[PanicHookSource, PanicHookDest](WithFunc[PanicHookSource, PanicHookDest](
+		func(source PanicHookSource, dest *PanicHookDest) {
+			var m map[string]string
+			m[source.Name] = dest.Name
+		})))
+	name := "first"
+	batch := PanicBatch{Items: []PanicItem{{Name: &name}, {}}}
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_, _ = MapTo[PanicBatchDTO](batch)
+		})
+	})
+	t.Run("Route", func(t *testing.T) {
+		Configure(WithPanicRecovery())
+		defer Configure()
+		_, err := MapTo[PanicBatchDTO](batch)
+		var panicErr *PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, reflect.TypeOf(PanicItem{}), panicErr.Source)
+		assert.Equal(t, reflect.TypeOf(PanicItemDTO{}), panicErr.Dest)
+		assert.Equal(t, "Items[1]", panicErr.Path)
+		assert.Contains(t, string(panicErr.Stack), "TestMapPanicRecovery")
+		assert.ErrorContains(t, err, "at field Items[1]: runtime error: invalid memory address or nil pointer dereference")
+	})
+	t.Run("Hook", func(t *testing.T) {
+		Configure(WithPanicRecovery())
+		defer Configure()
+		_, err := MapTo[PanicHookDest](PanicHookSource{Name: "name"})
+		var panicErr *PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, reflect.TypeOf(PanicHookSource{}), panicErr.Source)
+		assert.Equal(t, "", panicErr.Path)
+		assert.ErrorContains(t, err, "panic in route github.com/insei/gomapper.PanicHookSource -> "+
+			"github.com/insei/gomapper.PanicHookDest: assignment to entry in nil map")
+	})
+	t.Run("No panic", func(t *testing.T) {
+		Configure(WithPanicRecovery())
+		defer Configure()
+		dest, err := MapTo[[]PanicItemDTO]([]PanicItem{{Name: &name}})
+		assert.NoError(t, err)
+		assert.Equal(t, []PanicItemDTO{{Name: "first"}}, dest)
+	})
+}
+
+type ParallelItem struct {
+	ID   int
+	Name string
+	Tags []string
+}
+
+type ParallelItemDTO struct {
+	ID   int
+	Name string
+	Tags []string
+}
+
+type ParallelFailingItem struct {
+	ID int
+}
+
+type ParallelFailingItemDTO struct {
+	ID int
+}
+
+func newParallelItems(n int) []ParallelItem {
+	items := make([]ParallelItem, n)
+	for i := range items {
+		items[i] = ParallelItem{ID: i, Name: "item" + strconv.Itoa(i), Tags: []string{"tag"}}
+	}
+	return items
+}
+
+func TestMapParallel(t *testing.T) {
+	assert.NoError(t, AutoRoute[ParallelItem, ParallelItemDTO]())
+	assert.NoError(t, AddRoute[ParallelFailingItem, ParallelFailingItemDTO](func(source ParallelFailingItem, dest *ParallelFailingItemDTO) error {
+		if source.ID%2 == 1 {
+			return fmt.Errorf("odd id %d", source.ID)
+		}
+		dest.ID = source.ID
+		return nil
+	}))
+	items := newParallelItems(1000)
+
+	t.Run("Order", func(t *testing.T) {
+		dest := make([]ParallelItemDTO, 0)
+		assert.NoError(t, MapParallel(items, &dest, WithParallelism(4)))
+		assert.Len(t, dest, len(items))
+		for i, item := range dest {
+			assert.Equal(t, ParallelItemDTO(items[i]), item)
+		}
+	})
+	t.Run("Same as sequential", func(t *testing.T) {
+		sources := []*ParallelItem{&items[0], nil, &items[2]}
+		expected, err := MapTo[[]*ParallelItemDTO](sources)
+		assert.NoError(t, err)
+		var dest []*ParallelItemDTO
+		assert.NoError(t, MapParallel(sources, &dest))
+		assert.Equal(t, expected, dest)
+		assert.Nil(t, dest[1])
+	})
+	t.Run("Errors", func(t *testing.T) {
+		sources := []ParallelFailingItem{{ID: 0}, {ID: 1}, {ID: 2}, {ID: 3}}
+		var dest []ParallelFailingItemDTO
+		err := MapParallel(sources, &dest, WithParallelism(2))
+		assert.EqualError(t, err, "odd id 1")
+
+		_, err = MapTo[[]ParallelFailingItemDTO](sources)
+		assert.EqualError(t, err, "odd id 1")
+
+		// the error of the first failed element is returned, whichever worker fails first
+		sources = make([]ParallelFailingItem, 100)
+		sources[11] = ParallelFailingItem{ID: 1}
+		sources[90] = ParallelFailingItem{ID: 3}
+		dest = nil
+		err = MapParallel(sources, &dest, WithParallelism(4))
+		assert.EqualError(t, err, "odd id 1")
+	})
+	t.Run("Panic", func(t *testing.T) {
+		assert.NoError(t, AddRoute[PanicItem, PanicItemDTO](func(source PanicItem, dest *PanicItemDTO) error {
+			dest.Name = *source.Name
+			return nil
+		}, WithReplace()))
+		var dest []PanicItemDTO
+		func() {
+			defer func() {
+				err, ok := recover().(error)
+				assert.True(t, ok)
+				var runtimeErr runtime.Error
+				assert.ErrorAs(t, err, &runtimeErr)
+				// the stack of the worker is kept
+				assert.Contains(t, err.Error(), "mapper_test.go")
+			}()
+			_ = MapParallel([]PanicItem{{}, {}}, &dest, WithParallelism(2))
+		}()
+		Configure(WithPanicRecovery())
+		defer Configure()
+		var panicErr *PanicError
+		assert.ErrorAs(t, MapParallel([]PanicItem{{}, {}}, &dest, WithParallelism(2)), &panicErr)
+	})
+	t.Run("Reference tracking", func(t *testing.T) {
+		assert.NoError(t, AutoRoute[GraphNode, GraphNodeDTO](WithReplace()))
+		Configure(WithReferenceTracking())
+		defer Configure()
+		root := &GraphNode{Name: "root"}
+		for i := 0; i < 10; i++ {
+			root.Children = append(root.Children, &GraphNode{Name: "child" + strconv.Itoa(i), Parent: root})
+		}
+		dest := &GraphNodeDTO{}
+		assert.NoError(t, MapParallel(root, dest, WithParallelism(4)))
+		assert.Len(t, dest.Children, 10)
+		for _, child := range dest.Children {
+			assert.Same(t, dest, child.Parent)
+		}
+	})
+}
+
+func BenchmarkMapSlice(b *testing.B) {
+	_ = AutoRoute[ParallelItem, ParallelItemDTO](WithReplace())
+	items := newParallelItems(100_000)
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := MapTo[[]ParallelItemDTO](items); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var dest []ParallelItemDTO
+			if err := MapParallel(items, &dest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+type MapAsSource struct {
+	ID   int
+	Name string
+}
+
+type MapAsDest struct {
+	ID   int
+	Name string
+}
+
+type MapAsAutoDest struct {
+	ID   int
+	Name string
+}
+
+type MapAsTrackedDest struct {
+	ID int
+}
+
+func TestMapAs(t *testing.T) {
+	assert.NoError(t, AddRoute[MapAsSource, MapAsDest](func(source MapAsSource, dest *MapAsDest) error {
+		if source.ID < 0 {
+			return fmt.Errorf("negative id")
+		}
+		dest.ID = source.ID
+		dest.Name = source.Name
+		return nil
+	}))
+	assert.NoError(t, AddRoute[*MapAsSource, MapAsAutoDest](func(source *MapAsSource, dest *MapAsAutoDest) error {
+		dest.ID = source.ID
+		return nil
+	}))
+	source := MapAsSource{ID: 1, Name: "name"}
+
+	t.Run("Map", func(t *testing.T) {
+		dest, err := MapAs[MapAsSource, MapAsDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, MapAsDest{ID: 1, Name: "name"}, dest)
+		_, err = MapAs[MapAsSource, MapAsDest](MapAsSource{ID: -1})
+		assert.EqualError(t, err, "negative id")
+	})
+	t.Run("Allocations", func(t *testing.T) {
+		// the destination is the only allocation
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = MapAs[MapAsSource, MapAsDest](source)
+		})
+		assert.Equal(t, 1.0, allocs)
+		allocs = testing.AllocsPerRun(100, func() {
+			_, _ = MapAs[*MapAsSource, MapAsAutoDest](&source)
+		})
+		assert.Equal(t, 1.0, allocs)
+	})
+	t.Run("Pointer source", func(t *testing.T) {
+		dest, err := MapAs[*MapAsSource, MapAsAutoDest](&source)
+		assert.NoError(t, err)
+		assert.Equal(t, MapAsAutoDest{ID: 1}, dest)
+		_, err = MapAs[*MapAsSource, MapAsAutoDest](nil)
+		assert.ErrorContains(t, err, "source value can't be nil")
+	})
+	t.Run("Pointer source with reference tracking", func(t *testing.T) {
+		Configure(WithReferenceTracking())
+		defer Configure()
+		var tracked reflect.Value
+		assert.NoError(t, addRoute[*MapAsSource, MapAsTrackedDest](func(s *mapState, source *MapAsSource, dest *MapAsTrackedDest) error {
+			tracked = s.refs[refKey{source: source, dest: reflect.TypeOf(*dest)}]
+			return nil
+		}, &route{info: RouteInfo{Kind: RouteKindManual}}, false))
+		_, err := MapAs[*MapAsSource, MapAsTrackedDest](&source)
+		assert.NoError(t, err)
+		assert.True(t, tracked.IsValid())
+		assert.Equal(t, reflect.TypeOf(&MapAsTrackedDest{}), tracked.Type())
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		dest, err := MapAs[[]MapAsSource, []MapAsDest]([]MapAsSource{source})
+		assert.NoError(t, err)
+		assert.Equal(t, []MapAsDest{{ID: 1, Name: "name"}}, dest)
+		dest2, err := MapAs[*MapAsSource, MapAsDest](&source)
+		assert.NoError(t, err)
+		assert.Equal(t, MapAsDest{ID: 1, Name: "name"}, dest2)
+	})
+	t.Run("Replaced route", func(t *testing.T) {
+		_, _ = MapAs[MapAsSource, MapAsDest](source)
+		assert.NoError(t, AddRoute[MapAsSource, MapAsDest](func(source MapAsSource, dest *MapAsDest) error {
+			dest.Name = "replaced"
+			return nil
+		}, WithReplace()))
+		dest, err := MapAs[MapAsSource, MapAsDest](source)
+		assert.NoError(t, err)
+		assert.Equal(t, MapAsDest{Name: "replaced"}, dest)
+	})
+	t.Run("Panic recovery", func(t *testing.T) {
+		assert.NoError(t, AddRoute[MapAsSource, MapAsDest](func(source MapAsSource, dest *MapAsDest) error {
+			panic("mapping failed")
+		}, WithReplace()))
+		Configure(WithPanicRecovery())
+		defer Configure()
+		_, err := MapAs[MapAsSource, MapAsDest](source)
+		var panicErr *PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, "mapping failed", panicErr.Value)
+	})
+}
+
+func BenchmarkMapAs(b *testing.B) {
+	_ = AddRoute[MapAsSource, MapAsDest](func(source MapAsSource, dest *MapAsDest) error {
+		dest.ID = source.ID
+		dest.Name = source.Name
+		return nil
+	}, WithReplace())
+	source := MapAsSource{ID: 1, Name: "name"}
+	b.Run("MapTo", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = MapTo[MapAsDest](source)
+		}
+	})
+	b.Run("MapAs", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = MapAs[MapAsSource, MapAsDest](source)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package gomapper
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/insei/fmap/v3"
 )
 
@@ -8,48 +12,602 @@ type withFuncOption[TSource, TDest any] struct {
 	fn func(TSource, *TDest)
 }
 
-type withFieldSkip[TDest any] struct {
-	field fmap.Field
+type withFieldSkip[TSource any] struct {
+	field fieldSelector
+}
+
+type withDestFieldSkip[TDest any] struct {
+	field fieldSelector
+}
+
+type withFieldResolver[TSource, TDest, TField any] struct {
+	field    fieldSelector
+	selector func(*TDest) *TField
+	resolver func(TSource) (TField, error)
+}
+
+type withCondition[TSource any] struct {
+	field     fieldSelector
+	condition func(TSource) bool
+}
+
+type withValueCondition[TSource, TField any] struct {
+	field     fieldSelector
+	selector  func(*TSource) *TField
+	predicate func(TField) bool
+}
+
+type withDefault[TDest, TField any] struct {
+	field    fieldSelector
+	selector func(*TDest) *TField
+	value    TField
+}
+
+type withFieldRename[TSource, TDest any] struct {
+	source fieldSelector
+	dest   fieldSelector
+}
+
+type withIncludeBase[TSource, TDest any] struct{}
+
+type withMapKey[TStruct any] struct {
+	field fieldSelector
+	key   string
+}
+
+type withDottedKeys struct{}
+
+type withReverseMap struct{}
+
+type withReplace struct{}
+
+type withReferenceTracking struct{}
+
+type withPanicRecovery struct{}
+
+type withParallelism struct {
+	n int
+}
+
+type withDepthLimit struct {
+	limit depthLimit
+}
+
+// routeBase is the base route applied to embedded structs of derived types.
+type routeBase struct {
+	source reflect.Type
+	dest   reflect.Type
+	// sourcePath and destPath are paths of embedded structs resolved on validation
+	sourcePath string
+	destPath   string
+}
+
+// fieldSelector is a field selected by path on the struct type the selector function was declared on.
+type fieldSelector struct {
+	structType reflect.Type
+	path       string
+	err        error
+}
+
+// fieldResolver sets the destination field value resolved from the whole source.
+type fieldResolver struct {
+	field      fieldSelector
+	sourceType reflect.Type
+	resolve    func(source any, dest any) error
+}
+
+// fieldCondition allows mapping of the source field only when the check returns true.
+type fieldCondition struct {
+	field fieldSelector
+	check func(source any) bool
+}
+
+// fieldDefault sets the default value of the destination field when the field is unset after mapping.
+type fieldDefault struct {
+	field fieldSelector
+	set   func(dest any)
+}
+
+// mapKey is the key of the struct field in maps of map routes.
+type mapKey struct {
+	field fieldSelector
+	key   string
+}
+
+// fieldRename maps the source field to the destination field with another path.
+type fieldRename struct {
+	source fieldSelector
+	dest   fieldSelector
 }
 
 type options struct {
-	Fns      []any
-	Excluded []fmap.Field
+	Fns          []any
+	Excluded     []fieldSelector
+	DestExcluded []fieldSelector
+	Resolvers    []fieldResolver
+	Conditions   []fieldCondition
+	Defaults     []fieldDefault
+	Renames      []fieldRename
+	Bases        []routeBase
+	MapKeys      []mapKey
+	DottedKeys   bool
+	Depth        *depthLimit
+	Reverse      bool
+	Replace      bool
 }
 
 type Option interface {
 	apply(*options)
 }
 
+// ConfigOption is the global mapper option, see Configure.
+type ConfigOption interface {
+	applyConfig(*config)
+}
+
+// MapOption is the option of the single MapParallel call.
+type MapOption interface {
+	applyMap(*mapOptions)
+}
+
+type mapOptions struct {
+	parallelism int
+}
+
+// DepthOption is the option which can be used with AutoRoute as well as with Configure.
+type DepthOption interface {
+	Option
+	ConfigOption
+}
+
+// RouteOption is the option which can be used with AddRoute, AddConverter and AddPolymorphicRoute as well as with AutoRoute.
+type RouteOption interface {
+	Option
+	isRouteOption()
+}
+
+func applyRouteOptions(opts []RouteOption) *options {
+	opt := &options{}
+	for _, o := range opts {
+		o.apply(opt)
+	}
+	return opt
+}
+
 func (a withFuncOption[TSource, TDest]) apply(opts *options) {
 	opts.Fns = append(opts.Fns, a.fn)
 }
 
-func (a withFieldSkip[TDest]) apply(opts *options) {
-	if opts.Excluded == nil {
-		opts.Excluded = make([]fmap.Field, 0)
-	}
+func (a withFieldSkip[TSource]) apply(opts *options) {
 	opts.Excluded = append(opts.Excluded, a.field)
 }
 
+func (a withDestFieldSkip[TDest]) apply(opts *options) {
+	opts.DestExcluded = append(opts.DestExcluded, a.field)
+}
+
+func (a withFieldResolver[TSource, TDest, TField]) apply(opts *options) {
+	opts.Resolvers = append(opts.Resolvers, fieldResolver{
+		field:      a.field,
+		sourceType: reflect.TypeOf((*TSource)(nil)).Elem(),
+		resolve: func(source any, dest any) error {
+			value, err := a.resolver(*source.(*TSource))
+			if err != nil {
+				return err
+			}
+			*a.selector(dest.(*TDest)) = value
+			return nil
+		},
+	})
+}
+
+func (a withCondition[TSource]) apply(opts *options) {
+	opts.Conditions = append(opts.Conditions, fieldCondition{
+		field: a.field,
+		check: func(source any) bool {
+			return a.condition(*source.(*TSource))
+		},
+	})
+}
+
+func (a withValueCondition[TSource, TField]) apply(opts *options) {
+	opts.Conditions = append(opts.Conditions, fieldCondition{
+		field: a.field,
+		check: func(source any) bool {
+			s := *source.(*TSource)
+			return a.predicate(*a.selector(&s))
+		},
+	})
+}
+
+func (a withDefault[TDest, TField]) apply(opts *options) {
+	opts.Defaults = append(opts.Defaults, fieldDefault{
+		field: a.field,
+		set: func(dest any) {
+			fieldPtr := a.selector(dest.(*TDest))
+			if reflect.ValueOf(fieldPtr).Elem().IsZero() {
+				*fieldPtr = a.value
+			}
+		},
+	})
+}
+
+func (a withFieldRename[TSource, TDest]) apply(opts *options) {
+	opts.Renames = append(opts.Renames, fieldRename{source: a.source, dest: a.dest})
+}
+
+func (a withIncludeBase[TSource, TDest]) apply(opts *options) {
+	opts.Bases = append(opts.Bases, routeBase{
+		source: reflect.TypeOf((*TSource)(nil)).Elem(),
+		dest:   reflect.TypeOf((*TDest)(nil)).Elem(),
+	})
+}
+
+func (a withMapKey[TStruct]) apply(opts *options) {
+	opts.MapKeys = append(opts.MapKeys, mapKey{field: a.field, key: a.key})
+}
+
+func (a withDottedKeys) apply(opts *options) {
+	opts.DottedKeys = true
+}
+
+func (a withReverseMap) apply(opts *options) {
+	opts.Reverse = true
+}
+
+func (a withReplace) apply(opts *options) {
+	opts.Replace = true
+}
+
+func (a withReplace) isRouteOption() {}
+
+func (a withReferenceTracking) applyConfig(cfg *config) {
+	cfg.referenceTracking = true
+}
+
+func (a withPanicRecovery) applyConfig(cfg *config) {
+	cfg.recoverPanics = true
+}
+
+func (a withParallelism) applyMap(opts *mapOptions) {
+	opts.parallelism = a.n
+}
+
+func (a withDepthLimit) apply(opts *options) {
+	limit := a.limit
+	opts.Depth = &limit
+}
+
+func (a withDepthLimit) applyConfig(cfg *config) {
+	cfg.depth = a.limit
+}
+
 func WithFunc[TSource, TDest any](fn func(TSource, *TDest)) Option {
 	return &withFuncOption[TSource, TDest]{fn: fn}
 }
 
+// WithFieldSkip excludes the selected source field from auto mapping.
+// If the selected field is a struct, all of its nested fields are excluded too.
 func WithFieldSkip[TSource any](fn func(*TSource) any) Option {
-	source := new(TSource)
+	return &withFieldSkip[TSource]{field: selectField(fn)}
+}
+
+// WithDestFieldSkip excludes the selected destination field from auto mapping,
+// the field is never written by the route, but still can be set by WithFunc.
+// If the selected field is a struct, all of its nested fields are excluded too.
+func WithDestFieldSkip[TDest any](fn func(*TDest) any) Option {
+	return &withDestFieldSkip[TDest]{field: selectField(fn)}
+}
+
+// WithFieldResolver sets the selected destination field with the value returned by resolver,
+// the field (and its nested fields if the field is a struct) is excluded from auto mapping.
+// Resolver errors are returned from mapping.
+func WithFieldResolver[TSource, TDest, TField any](destSelector func(*TDest) *TField, resolver func(TSource) (TField, error)) Option {
+	return &withFieldResolver[TSource, TDest, TField]{
+		field: selectField(func(dest *TDest) any {
+			return destSelector(dest)
+		}),
+		selector: destSelector,
+		resolver: resolver,
+	}
+}
+
+// WithCondition maps the selected source field (and its nested fields if the field is a struct)
+// only when the condition on the source returns true.
+func WithCondition[TSource any](fieldSelector func(*TSource) any, condition func(TSource) bool) Option {
+	return &withCondition[TSource]{field: selectField(fieldSelector), condition: condition}
+}
+
+// WithValueCondition maps the selected source field (and its nested fields if the field is a struct)
+// only when the predicate on the field value returns true.
+func WithValueCondition[TSource, TField any](fieldSelector func(*TSource) *TField, predicate func(TField) bool) Option {
+	return &withValueCondition[TSource, TField]{
+		field: selectField(func(source *TSource) any {
+			return fieldSelector(source)
+		}),
+		selector:  fieldSelector,
+		predicate: predicate,
+	}
+}
+
+// WithDefault sets the selected destination field to the value, when the field has a zero value after mapping.
+// Defaults can also be declared with `default:"value"` tag on destination fields of scalar types and pointers to them,
+// defaults from options take precedence over defaults from tags.
+func WithDefault[TDest, TField any](fieldSelector func(*TDest) *TField, value TField) Option {
+	return &withDefault[TDest, TField]{
+		field: selectField(func(dest *TDest) any {
+			return fieldSelector(dest)
+		}),
+		selector: fieldSelector,
+		value:    value,
+	}
+}
+
+// WithFieldRename maps the selected source field to the selected destination field.
+// If the selected fields are structs, nested fields are mapped by names relative to the selected fields.
+func WithFieldRename[TSource, TDest any](sourceSelector func(*TSource) any, destSelector func(*TDest) any) Option {
+	return &withFieldRename[TSource, TDest]{source: selectField(sourceSelector), dest: selectField(destSelector)}
+}
+
+// IncludeBase maps the embedded TSource struct to the embedded TDest struct with the registered route
+// from TSource to TDest, so renames, skips and hooks of the base route are applied to the embedded part
+// of derived types. Fields of embedded structs are not matched by the derived route.
+func IncludeBase[TSource, TDest any]() Option {
+	return &withIncludeBase[TSource, TDest]{}
+}
+
+// WithMapKey sets the key of the struct field in maps of AutoMapRoute, by default the field name is used.
+// Keys of nested fields are joined from keys of their parents.
+func WithMapKey[TStruct any](fieldSelector func(*TStruct) any, key string) Option {
+	return &withMapKey[TStruct]{field: selectField(fieldSelector), key: key}
+}
+
+// WithDottedKeys stores fields of nested structs with dotted keys in maps of AutoMapRoute, i.e. Address.City,
+// instead of nested maps.
+func WithDottedKeys() Option {
+	return &withDottedKeys{}
+}
+
+// ReverseMap registers the reverse route in addition to the auto route, renamed and flattened fields are
+// mapped back, skipped fields are skipped in both directions. Hooks, conditions, resolvers and defaults
+// can't be inverted, they are applied to the forward route only and listed in RouteInfo.NotInverted
+// and RouteExplanation.NotInverted of the reverse route.
+func ReverseMap() Option {
+	return &withReverseMap{}
+}
+
+// WithReplace allows to replace the already registered route, by default the registration of the route
+// between already mapped types returns an error.
+func WithReplace() RouteOption {
+	return &withReplace{}
+}
+
+// WithReferenceTracking enables mapping of each source pointer once per Map call, repeated references
+// to the source pointer are mapped to the same destination pointer, so the shape of graphs and cycles is preserved.
+// The top level source is tracked only when it's passed to Map by pointer.
+func WithReferenceTracking() ConfigOption {
+	return &withReferenceTracking{}
+}
+
+// WithPanicRecovery enables the recovery of panics in mapping functions, hooks, resolvers and conditions of routes,
+// Map returns PanicError with types of the route, the path of the field being mapped and the stack trace instead.
+func WithPanicRecovery() ConfigOption {
+	return &withPanicRecovery{}
+}
 
-	storage, err := fmap.GetFrom(source)
+// WithParallelism sets the number of workers mapping elements of slices with MapParallel,
+// by default it's GOMAXPROCS. Slices are mapped sequentially when n is less than 2.
+func WithParallelism(n int) MapOption {
+	return &withParallelism{n: n}
+}
+
+// WithMaxDepth limits the depth of fields mapped with nested routes and pointer fields, MaxDepthError with
+// the path of the source field is returned when the limit is exceeded. Used with AutoRoute the depth is counted
+// from the source mapped with the route, used with Configure the depth is counted from the source passed to Map.
+// The default max depth is 1000.
+func WithMaxDepth(n int) DepthOption {
+	return &withDepthLimit{limit: depthLimit{max: n}}
+}
+
+// WithDepthLimit limits the depth like WithMaxDepth, but fields and slice elements past the limit are left zero
+// instead of returning the error.
+func WithDepthLimit(n int) DepthOption {
+	return &withDepthLimit{limit: depthLimit{max: n, truncate: true}}
+}
+
+func selectField[T any](fn func(*T) any) fieldSelector {
+	obj := new(T)
+	selector := fieldSelector{structType: reflect.TypeOf(obj).Elem()}
+
+	storage, err := fmap.GetFrom(obj)
 	if err != nil {
-		panic(err)
+		selector.err = err
+		return selector
 	}
 
-	fieldPtr := fn(source)
+	fieldPtr := fn(obj)
+	if fieldPtr == nil {
+		selector.err = fmt.Errorf("field selector returned nil")
+		return selector
+	}
 
-	field, err := storage.GetFieldByPtr(source, fieldPtr)
+	field, err := storage.GetFieldByPtr(obj, fieldPtr)
+	if err != nil || field.GetType() != reflect.TypeOf(fieldPtr).Elem() {
+		// fmap matches fields by offset and kind only and stores offsets of deeply nested struct fields
+		// relative to their parent struct, so fields are matched by pointers resolved with findField
+		field, err = findFieldByPtr(storage, obj, fieldPtr)
+	}
 	if err != nil {
-		panic(err)
+		selector.err = err
+		return selector
 	}
+	selector.path = field.GetStructPath()
+	return selector
+}
 
-	return &withFieldSkip[TSource]{field: field}
+func findFieldByPtr(storage fmap.Storage, structPtr, fieldPtr any) (fmap.Field, error) {
+	fieldPtrOf := reflect.ValueOf(fieldPtr)
+	if fieldPtrOf.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("not supported type: %v, only ptr to types is supported", fieldPtrOf.Type())
+	}
+	for _, path := range storage.GetAllPaths() {
+		field, _ := findField(storage, path)
+		ptrOf := reflect.ValueOf(field.GetPtr(structPtr))
+		if ptrOf.Type() == fieldPtrOf.Type() && ptrOf.Pointer() == fieldPtrOf.Pointer() {
+			return field, nil
+		}
+	}
+	return nil, fmt.Errorf("field of type %s not found", fieldPtrOf.Type().Elem())
+}
+
+func (s fieldSelector) validate(structType reflect.Type) error {
+	if s.err != nil {
+		return fmt.Errorf("invalid field selector on %s: %w", getTypeNameRecursive(s.structType, ""), s.err)
+	}
+	if s.structType != structType {
+		return fmt.Errorf("field %s is selected on %s, but expected %s", s.path,
+			getTypeNameRecursive(s.structType, ""), getTypeNameRecursive(structType, ""))
+	}
+	return nil
+}
+
+// matches reports whether the path is the selected field path or one of its nested field paths.
+func (s fieldSelector) matches(path string) bool {
+	return path == s.path || strings.HasPrefix(path, s.path+".")
+}
+
+func (o *options) validate(sourceType, destType reflect.Type) error {
+	for _, s := range o.Excluded {
+		if err := s.validate(sourceType); err != nil {
+			return fmt.Errorf("source field skip: %w", err)
+		}
+	}
+	for _, s := range o.DestExcluded {
+		if err := s.validate(destType); err != nil {
+			return fmt.Errorf("destination field skip: %w", err)
+		}
+	}
+	for _, r := range o.Renames {
+		if err := r.source.validate(sourceType); err != nil {
+			return fmt.Errorf("source field rename: %w", err)
+		}
+		if err := r.dest.validate(destType); err != nil {
+			return fmt.Errorf("destination field rename: %w", err)
+		}
+	}
+	for _, k := range o.MapKeys {
+		if err := k.field.validate(sourceType); err != nil {
+			return fmt.Errorf("map key: %w", err)
+		}
+	}
+	for _, c := range o.Conditions {
+		if err := c.field.validate(sourceType); err != nil {
+			return fmt.Errorf("field condition: %w", err)
+		}
+	}
+	for _, r := range o.Resolvers {
+		if r.sourceType != sourceType {
+			return fmt.Errorf("field resolver source type is %s, but expected %s",
+				getTypeNameRecursive(r.sourceType, ""), getTypeNameRecursive(sourceType, ""))
+		}
+		if err := r.field.validate(destType); err != nil {
+			return fmt.Errorf("field resolver: %w", err)
+		}
+	}
+	for _, d := range o.Defaults {
+		if err := d.field.validate(destType); err != nil {
+			return fmt.Errorf("field default: %w", err)
+		}
+	}
+	if o.Depth != nil && o.Depth.max < 0 {
+		return fmt.Errorf("max depth can't be negative")
+	}
+	for i := range o.Bases {
+		if err := o.Bases[i].resolve(sourceType, destType, o.Reverse); err != nil {
+			return fmt.Errorf("base route: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolve finds embedded structs of base types and checks that base routes are registered.
+func (b *routeBase) resolve(sourceType, destType reflect.Type, reverse bool) error {
+	var ok bool
+	if b.sourcePath, ok = findEmbeddedField(sourceType, b.source, !reverse); !ok {
+		return fmt.Errorf("type %s is not embedded in %s",
+			getTypeNameRecursive(b.source, ""), getTypeNameRecursive(sourceType, ""))
+	}
+	if b.destPath, ok = findEmbeddedField(destType, b.dest, false); !ok {
+		return fmt.Errorf("type %s is not embedded in %s",
+			getTypeNameRecursive(b.dest, ""), getTypeNameRecursive(destType, ""))
+	}
+	pairs := [][2]reflect.Type{{b.source, b.dest}}
+	if reverse {
+		pairs = append(pairs, [2]reflect.Type{b.dest, b.source})
+	}
+	for _, p := range pairs {
+		if r, _ := findRoute(p[0], reflect.PointerTo(p[1])); r == nil {
+			return fmt.Errorf("route not found for type %s to type %s",
+				getTypeNameRecursive(p[0], ""), getTypeNameRecursive(p[1], ""))
+		}
+	}
+	return nil
+}
+
+// findEmbeddedField returns the name of the field of structType embedding typ,
+// pointers are allowed for source types only, because the destination is mapped in place.
+func findEmbeddedField(structType, typ reflect.Type, allowPointer bool) (string, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		f := structType.Field(i)
+		if f.Anonymous && (f.Type == typ || (allowPointer && f.Type == reflect.PointerTo(typ))) {
+			return f.Name, true
+		}
+	}
+	return "", false
+}
+
+// isBaseField reports whether the source or destination path belongs to the embedded struct of the base route.
+func (o *options) isBaseField(path string, source bool) bool {
+	for _, b := range o.Bases {
+		basePath := b.destPath
+		if source {
+			basePath = b.sourcePath
+		}
+		if path == basePath || strings.HasPrefix(path, basePath+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// isDestFieldExcluded reports whether the destination field is not mapped automatically.
+func (o *options) isDestFieldExcluded(path string) bool {
+	if isFieldSelected(path, o.DestExcluded) {
+		return true
+	}
+	for _, r := range o.Resolvers {
+		if r.field.matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRenameTarget reports whether the destination field is mapped from a renamed source field.
+func (o *options) isRenameTarget(path string) bool {
+	for _, r := range o.Renames {
+		if r.dest.matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func isFieldSelected(path string, selectors []fieldSelector) bool {
+	for _, s := range selectors {
+		if s.matches(path) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,118 @@
+package gomapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SubRoute is the pair of concrete types of the polymorphic route.
+type SubRoute struct {
+	source reflect.Type
+	dest   reflect.Type
+}
+
+// subRoute is the sub route resolved for the polymorphic route.
+type subRoute struct {
+	source reflect.Type
+	// dest is the destination type without a pointer, the mapping destination
+	dest reflect.Type
+	// destPtr is true, when the pointer to dest implements the destination interface
+	destPtr bool
+}
+
+// WithSubRoute declares that the source of TSource type is mapped to the destination of TDest type
+// by the polymorphic route. The route from TSource to TDest should be registered before mapping.
+func WithSubRoute[TSource, TDest any]() SubRoute {
+	return SubRoute{
+		source: reflect.TypeOf((*TSource)(nil)).Elem(),
+		dest:   reflect.TypeOf((*TDest)(nil)).Elem(),
+	}
+}
+
+// AddPolymorphicRoute registers the route between interfaces, the source is mapped to the concrete destination
+// type selected by the dynamic type of the source with sub routes. The route is used for sources of types
+// implementing TSourceIface as any other interface route, so slices and fields of TSourceIface type are mapped
+// element by element to the right destination types.
+func AddPolymorphicRoute[TSourceIface, TDestIface any](subRoutes []SubRoute, opts ...RouteOption) error {
+	sourceType := reflect.TypeOf((*TSourceIface)(nil)).Elem()
+	destType := reflect.TypeOf((*TDestIface)(nil)).Elem()
+	if sourceType.Kind() != reflect.Interface || destType.Kind() != reflect.Interface {
+		return fmt.Errorf("polymorphic route types should be interfaces, route: %s -> %s",
+			getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destType, ""))
+	}
+
+	resolved := make([]subRoute, 0, len(subRoutes))
+	for _, sr := range subRoutes {
+		r, err := resolveSubRoute(sr, sourceType, destType)
+		if err != nil {
+			return fmt.Errorf("%w, route: %s -> %s", err,
+				getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destType, ""))
+		}
+		for _, existing := range resolved {
+			if existing.source == r.source {
+				return fmt.Errorf("duplicate sub route for type %s, route: %s -> %s",
+					getTypeNameRecursive(r.source, ""), getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destType, ""))
+			}
+		}
+		resolved = append(resolved, r)
+	}
+
+	opt := applyRouteOptions(opts)
+	mapFunc := func(s *mapState, source TSourceIface, dest *TDestIface) error {
+		sr, ok := findSubRoute(resolved, reflect.TypeOf(source))
+		if !ok {
+			return fmt.Errorf("sub route not found for type %s, route: %s -> %s", getTypeName(source),
+				getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destType, ""))
+		}
+		concreteDest := reflect.New(sr.dest)
+		if err := s.mapValue(source, concreteDest.Interface()); err != nil {
+			return err
+		}
+		if sr.destPtr {
+			*dest = concreteDest.Interface().(TDestIface)
+			return nil
+		}
+		*dest = concreteDest.Elem().Interface().(TDestIface)
+		return nil
+	}
+	return addRoute[TSourceIface, TDestIface](mapFunc, &route{
+		info: RouteInfo{Kind: RouteKindPolymorphic, CallSite: getCallSite(1)},
+	}, opt.Replace)
+}
+
+func resolveSubRoute(sr SubRoute, sourceIface, destIface reflect.Type) (subRoute, error) {
+	if !sr.source.Implements(sourceIface) && !reflect.PointerTo(sr.source).Implements(sourceIface) {
+		return subRoute{}, fmt.Errorf("sub route source type %s doesn't implement %s",
+			getTypeNameRecursive(sr.source, ""), getTypeNameRecursive(sourceIface, ""))
+	}
+	dest := sr.dest
+	if dest.Kind() == reflect.Ptr {
+		dest = dest.Elem()
+	}
+	switch {
+	case dest.Implements(destIface):
+		return subRoute{source: sr.source, dest: dest}, nil
+	case reflect.PointerTo(dest).Implements(destIface):
+		return subRoute{source: sr.source, dest: dest, destPtr: true}, nil
+	default:
+		return subRoute{}, fmt.Errorf("sub route destination type %s doesn't implement %s",
+			getTypeNameRecursive(sr.dest, ""), getTypeNameRecursive(destIface, ""))
+	}
+}
+
+// findSubRoute returns the sub route for the dynamic source type, sub routes for the same type are preferred
+// over sub routes for the pointer or dereferenced type.
+func findSubRoute(subRoutes []subRoute, sourceType reflect.Type) (subRoute, bool) {
+	for _, sr := range subRoutes {
+		if sr.source == sourceType {
+			return sr, true
+		}
+	}
+	for _, sr := range subRoutes {
+		if reflect.PointerTo(sr.source) == sourceType || (sourceType.Kind() == reflect.Ptr && sr.source == sourceType.Elem()) ||
+			(sr.source.Kind() == reflect.Ptr && sr.source.Elem() == sourceType) {
+			return sr, true
+		}
+	}
+	return subRoute{}, false
+}
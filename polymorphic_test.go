@@ -0,0 +1,132 @@
+package gomapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type PolyEvent interface {
+	EventID() string
+}
+
+type PolyUserCreated struct {
+	ID   string
+	Name string
+}
+
+func (e PolyUserCreated) EventID() string { return e.ID }
+
+type PolyOrderPlaced struct {
+	ID     string
+	Amount int
+}
+
+func (e *PolyOrderPlaced) EventID() string { return e.ID }
+
+type PolyUnknownEvent struct {
+	ID string
+}
+
+func (e PolyUnknownEvent) EventID() string { return e.ID }
+
+type PolyEventDTO interface {
+	EventType() string
+}
+
+type PolyUserCreatedDTO struct {
+	ID   string
+	Name string
+}
+
+func (d PolyUserCreatedDTO) EventType() string { return "user_created" }
+
+type PolyOrderPlacedDTO struct {
+	ID     string
+	Amount int
+}
+
+func (d *PolyOrderPlacedDTO) EventType() string { return "order_placed" }
+
+type PolyEventHolder struct {
+	Event  PolyEvent
+	Events []PolyEvent
+}
+
+type PolyEventHolderDTO struct {
+	Event  PolyEventDTO
+	Events []PolyEventDTO
+}
+
+func TestAddPolymorphicRoute(t *testing.T) {
+	assert.NoError(t, AutoRoute[PolyUserCreated, PolyUserCreatedDTO]())
+	assert.NoError(t, AutoRoute[PolyOrderPlaced, PolyOrderPlacedDTO]())
+	err := AddPolymorphicRoute[PolyEvent, PolyEventDTO]([]SubRoute{
+		WithSubRoute[PolyUserCreated, PolyUserCreatedDTO](),
+		WithSubRoute[PolyOrderPlaced, PolyOrderPlacedDTO](),
+	})
+	assert.NoError(t, err)
+
+	t.Run("Concrete destination is selected by source type", func(t *testing.T) {
+		dest, err := MapTo[PolyEventDTO](PolyUserCreated{ID: "1", Name: "Test"})
+		assert.NoError(t, err)
+		assert.Equal(t, PolyUserCreatedDTO{ID: "1", Name: "Test"}, dest)
+		dest, err = MapTo[PolyEventDTO](&PolyOrderPlaced{ID: "2", Amount: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, &PolyOrderPlacedDTO{ID: "2", Amount: 10}, dest)
+	})
+	t.Run("Slice of interfaces", func(t *testing.T) {
+		events := []PolyEvent{PolyUserCreated{ID: "1", Name: "Test"}, &PolyOrderPlaced{ID: "2", Amount: 10}}
+		dest, err := MapTo[[]PolyEventDTO](events)
+		assert.NoError(t, err)
+		assert.Equal(t, []PolyEventDTO{PolyUserCreatedDTO{ID: "1", Name: "Test"}, &PolyOrderPlacedDTO{ID: "2", Amount: 10}}, dest)
+	})
+	t.Run("Auto route fields of interface type", func(t *testing.T) {
+		assert.NoError(t, AutoRoute[PolyEventHolder, PolyEventHolderDTO]())
+		source := PolyEventHolder{
+			Event:  &PolyOrderPlaced{ID: "1", Amount: 5},
+			Events: []PolyEvent{PolyUserCreated{ID: "2", Name: "Test"}},
+		}
+		dest, err := MapTo[PolyEventHolderDTO](source)
+		assert.NoError(t, err)
+		assert.Equal(t, &PolyOrderPlacedDTO{ID: "1", Amount: 5}, dest.Event)
+		assert.Equal(t, []PolyEventDTO{PolyUserCreatedDTO{ID: "2", Name: "Test"}}, dest.Events)
+	})
+	t.Run("Sub route not found", func(t *testing.T) {
+		_, err := MapTo[PolyEventDTO](PolyUnknownEvent{ID: "1"})
+		assert.ErrorContains(t, err, "sub route not found")
+	})
+	t.Run("Route info", func(t *testing.T) {
+		info, ok := findRouteInfo(reflect.TypeOf((*PolyEvent)(nil)).Elem(), reflect.TypeOf((*PolyEventDTO)(nil)).Elem())
+		assert.True(t, ok)
+		assert.Equal(t, RouteKindPolymorphic, info.Kind)
+	})
+	t.Run("Invalid sub routes", func(t *testing.T) {
+		err := AddPolymorphicRoute[PolyEvent, PolyEventDTO]([]SubRoute{WithSubRoute[PolyUserCreated, PolyOrderPlaced]()})
+		assert.ErrorContains(t, err, "doesn't implement")
+		err = AddPolymorphicRoute[PolyEvent, PolyEventDTO]([]SubRoute{WithSubRoute[PolyEventHolder, PolyUserCreatedDTO]()})
+		assert.ErrorContains(t, err, "doesn't implement")
+		err = AddPolymorphicRoute[PolyEvent, PolyEventDTO]([]SubRoute{
+			WithSubRoute[PolyUserCreated, PolyUserCreatedDTO](),
+			WithSubRoute[PolyUserCreated, PolyOrderPlacedDTO](),
+		})
+		assert.ErrorContains(t, err, "duplicate sub route")
+		err = AddPolymorphicRoute[PolyUserCreated, PolyEventDTO](nil)
+		assert.ErrorContains(t, err, "should be interfaces")
+	})
+	t.Run("Duplicate route", func(t *testing.T) {
+		err := AddPolymorphicRoute[PolyEvent, PolyEventDTO]([]SubRoute{WithSubRoute[PolyUserCreated, PolyUserCreatedDTO]()})
+		assert.ErrorContains(t, err, "WithReplace")
+	})
+	t.Run("Replace route", func(t *testing.T) {
+		err := AddPolymorphicRoute[PolyEvent, PolyEventDTO]([]SubRoute{
+			WithSubRoute[PolyUserCreated, PolyUserCreatedDTO](),
+			WithSubRoute[PolyOrderPlaced, PolyOrderPlacedDTO](),
+		}, WithReplace())
+		assert.NoError(t, err)
+		dest, err := MapTo[PolyEventDTO](&PolyOrderPlaced{ID: "2", Amount: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, &PolyOrderPlacedDTO{ID: "2", Amount: 10}, dest)
+	})
+}
@@ -0,0 +1,19 @@
+module github.com/insei/gomapper/protomap
+
+go 1.23.0
+
+require (
+	github.com/insei/gomapper v0.0.0-20261019164037-019823c4413d
+	github.com/stretchr/testify v1.9.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/insei/fmap/v3 v3.1.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// protomap is developed and tested with the local mapper
+replace github.com/insei/gomapper => ../
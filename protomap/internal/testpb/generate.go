@@ -0,0 +1,4 @@
+// Package testpb contains protobuf messages of protomap tests.
+package testpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative test.proto
@@ -0,0 +1,459 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: test.proto
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+	Status_STATUS_BLOCKED     Status = 2
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+		2: "STATUS_BLOCKED",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+		"STATUS_BLOCKED":     2,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_test_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{0}
+}
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Street        string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_test_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+type User struct {
+	state          protoimpl.MessageState  `protogen:"open.v1"`
+	Id             string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt      *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SessionTimeout *durationpb.Duration    `protobuf:"bytes,4,opt,name=session_timeout,json=sessionTimeout,proto3" json:"session_timeout,omitempty"`
+	Nickname       *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Age            *wrapperspb.Int64Value  `protobuf:"bytes,6,opt,name=age,proto3" json:"age,omitempty"`
+	Verified       *wrapperspb.BoolValue   `protobuf:"bytes,7,opt,name=verified,proto3" json:"verified,omitempty"`
+	Address        *Address                `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
+	Tags           []string                `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	//	*User_Postal
+	Contact isUser_Contact `protobuf_oneof:"contact"`
+	Title   *string        `protobuf:"bytes,13,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// Types that are valid to be assigned to State:
+	//
+	//	*User_Status
+	//	*User_DeletedAt
+	State         isUser_State `protobuf_oneof:"state"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_test_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetSessionTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.SessionTimeout
+	}
+	return nil
+}
+
+func (x *User) GetNickname() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Nickname
+	}
+	return nil
+}
+
+func (x *User) GetAge() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+func (x *User) GetVerified() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Verified
+	}
+	return nil
+}
+
+func (x *User) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *User) GetContact() isUser_Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *User) GetPhone() string {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Phone); ok {
+			return x.Phone
+		}
+	}
+	return ""
+}
+
+func (x *User) GetPostal() *Address {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Postal); ok {
+			return x.Postal
+		}
+	}
+	return nil
+}
+
+func (x *User) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *User) GetState() isUser_State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *User) GetStatus() Status {
+	if x != nil {
+		if x, ok := x.State.(*User_Status); ok {
+			return x.Status
+		}
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *User) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.State.(*User_DeletedAt); ok {
+			return x.DeletedAt
+		}
+	}
+	return nil
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Email struct {
+	Email string `protobuf:"bytes,10,opt,name=email,proto3,oneof"`
+}
+
+type User_Phone struct {
+	Phone string `protobuf:"bytes,11,opt,name=phone,proto3,oneof"`
+}
+
+type User_Postal struct {
+	Postal *Address `protobuf:"bytes,12,opt,name=postal,proto3,oneof"`
+}
+
+func (*User_Email) isUser_Contact() {}
+
+func (*User_Phone) isUser_Contact() {}
+
+func (*User_Postal) isUser_Contact() {}
+
+type isUser_State interface {
+	isUser_State()
+}
+
+type User_Status struct {
+	Status Status `protobuf:"varint,14,opt,name=status,proto3,enum=gomapper.protomap.test.Status,oneof"`
+}
+
+type User_DeletedAt struct {
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=deleted_at,json=deletedAt,proto3,oneof"`
+}
+
+func (*User_Status) isUser_State() {}
+
+func (*User_DeletedAt) isUser_State() {}
+
+var File_test_proto protoreflect.FileDescriptor
+
+const file_test_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"test.proto\x12\x16gomapper.protomap.test\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"5\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x16\n" +
+	"\x06street\x18\x02 \x01(\tR\x06street\"\xb4\x05\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12B\n" +
+	"\x0fsession_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0esessionTimeout\x128\n" +
+	"\bnickname\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\bnickname\x12-\n" +
+	"\x03age\x18\x06 \x01(\v2\x1b.google.protobuf.Int64ValueR\x03age\x126\n" +
+	"\bverified\x18\a \x01(\v2\x1a.google.protobuf.BoolValueR\bverified\x129\n" +
+	"\aaddress\x18\b \x01(\v2\x1f.gomapper.protomap.test.AddressR\aaddress\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x16\n" +
+	"\x05email\x18\n" +
+	" \x01(\tH\x00R\x05email\x12\x16\n" +
+	"\x05phone\x18\v \x01(\tH\x00R\x05phone\x129\n" +
+	"\x06postal\x18\f \x01(\v2\x1f.gomapper.protomap.test.AddressH\x00R\x06postal\x12\x19\n" +
+	"\x05title\x18\r \x01(\tH\x02R\x05title\x88\x01\x01\x128\n" +
+	"\x06status\x18\x0e \x01(\x0e2\x1e.gomapper.protomap.test.StatusH\x01R\x06status\x12;\n" +
+	"\n" +
+	"deleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tdeletedAtB\t\n" +
+	"\acontactB\a\n" +
+	"\x05stateB\b\n" +
+	"\x06_title*G\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATUS_ACTIVE\x10\x01\x12\x12\n" +
+	"\x0eSTATUS_BLOCKED\x10\x02B4Z2github.com/insei/gomapper/protomap/internal/testpbb\x06proto3"
+
+var (
+	file_test_proto_rawDescOnce sync.Once
+	file_test_proto_rawDescData []byte
+)
+
+func file_test_proto_rawDescGZIP() []byte {
+	file_test_proto_rawDescOnce.Do(func() {
+		file_test_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_test_proto_rawDesc), len(file_test_proto_rawDesc)))
+	})
+	return file_test_proto_rawDescData
+}
+
+var file_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_test_proto_goTypes = []any{
+	(Status)(0),                    // 0: gomapper.protomap.test.Status
+	(*Address)(nil),                // 1: gomapper.protomap.test.Address
+	(*User)(nil),                   // 2: gomapper.protomap.test.User
+	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),    // 4: google.protobuf.Duration
+	(*wrapperspb.StringValue)(nil), // 5: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),  // 6: google.protobuf.Int64Value
+	(*wrapperspb.BoolValue)(nil),   // 7: google.protobuf.BoolValue
+}
+var file_test_proto_depIdxs = []int32{
+	3, // 0: gomapper.protomap.test.User.created_at:type_name -> google.protobuf.Timestamp
+	4, // 1: gomapper.protomap.test.User.session_timeout:type_name -> google.protobuf.Duration
+	5, // 2: gomapper.protomap.test.User.nickname:type_name -> google.protobuf.StringValue
+	6, // 3: gomapper.protomap.test.User.age:type_name -> google.protobuf.Int64Value
+	7, // 4: gomapper.protomap.test.User.verified:type_name -> google.protobuf.BoolValue
+	1, // 5: gomapper.protomap.test.User.address:type_name -> gomapper.protomap.test.Address
+	1, // 6: gomapper.protomap.test.User.postal:type_name -> gomapper.protomap.test.Address
+	0, // 7: gomapper.protomap.test.User.status:type_name -> gomapper.protomap.test.Status
+	3, // 8: gomapper.protomap.test.User.deleted_at:type_name -> google.protobuf.Timestamp
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_test_proto_init() }
+func file_test_proto_init() {
+	if File_test_proto != nil {
+		return
+	}
+	file_test_proto_msgTypes[1].OneofWrappers = []any{
+		(*User_Email)(nil),
+		(*User_Phone)(nil),
+		(*User_Postal)(nil),
+		(*User_Status)(nil),
+		(*User_DeletedAt)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_test_proto_rawDesc), len(file_test_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_test_proto_goTypes,
+		DependencyIndexes: file_test_proto_depIdxs,
+		EnumInfos:         file_test_proto_enumTypes,
+		MessageInfos:      file_test_proto_msgTypes,
+	}.Build()
+	File_test_proto = out.File
+	file_test_proto_goTypes = nil
+	file_test_proto_depIdxs = nil
+}
@@ -0,0 +1,207 @@
+// Package protomap integrates gomapper with generated protobuf messages. After Register auto routes skip internals
+// of messages, map members of oneofs as flat fields and convert well-known types to Go types:
+//
+//   - *timestamppb.Timestamp to time.Time and *time.Time;
+//   - *durationpb.Duration to time.Duration and *time.Duration;
+//   - wrapperspb wrappers, i.e. *wrapperspb.StringValue, to their values and pointers to them.
+//
+// Nil messages are converted to zero values and nil pointers, zero values and nil pointers are converted to nil
+// messages. Members of oneofs are mapped to and from fields with the same names, only non-zero fields set oneofs.
+// Messages must not be copied, so routes are registered between pointers to messages and structs, they read
+// and write messages through pointers.
+//
+//	type User struct {
+//		Name      string
+//		CreatedAt time.Time
+//		Nickname  *string
+//		Email     string // the member of the contact oneof
+//		Phone     string // the member of the contact oneof
+//	}
+//
+//	err := protomap.Register()
+//	err = gomapper.AutoRouteBidirectional[*pb.User, User]()
+package protomap
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insei/gomapper"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	messageType  = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	registerOnce sync.Once
+)
+
+// Register registers converters of well-known types and changes fields of messages for auto routes registered
+// after it. Options are applied to converters, i.e. WithReplace replaces converters registered before.
+func Register(opts ...gomapper.RouteOption) error {
+	registerOnce.Do(func() {
+		gomapper.AddStructFields(messageFields)
+	})
+	return errors.Join(
+		addConverters(timestamppb.New, func(m *timestamppb.Timestamp) (time.Time, error) {
+			if err := m.CheckValid(); err != nil {
+				return time.Time{}, err
+			}
+			return m.AsTime(), nil
+		}, opts),
+		addConverters(durationpb.New, func(m *durationpb.Duration) (time.Duration, error) {
+			if err := m.CheckValid(); err != nil {
+				return 0, err
+			}
+			return m.AsDuration(), nil
+		}, opts),
+		addWrapperConverters(wrapperspb.String, opts),
+		addWrapperConverters(wrapperspb.Bool, opts),
+		addWrapperConverters(wrapperspb.Int32, opts),
+		addWrapperConverters(wrapperspb.Int64, opts),
+		addWrapperConverters(wrapperspb.UInt32, opts),
+		addWrapperConverters(wrapperspb.UInt64, opts),
+		addWrapperConverters(wrapperspb.Float, opts),
+		addWrapperConverters(wrapperspb.Double, opts),
+		addWrapperConverters(wrapperspb.Bytes, opts),
+	)
+}
+
+// addWrapperConverters registers converters of the wrapperspb wrapper created with wrap.
+func addWrapperConverters[TMessage interface{ GetValue() TValue }, TValue any](wrap func(TValue) TMessage,
+	opts []gomapper.RouteOption) error {
+	return addConverters(wrap, func(m TMessage) (TValue, error) {
+		return m.GetValue(), nil
+	}, opts)
+}
+
+// addConverters registers converters between the message and the value and the pointer to it.
+func addConverters[TMessage, TValue any](wrap func(TValue) TMessage, unwrap func(TMessage) (TValue, error),
+	opts []gomapper.RouteOption) error {
+	var zero TMessage
+	return errors.Join(
+		gomapper.AddConverter(func(value TValue) (TMessage, error) {
+			if reflect.ValueOf(&value).Elem().IsZero() {
+				return zero, nil
+			}
+			return wrap(value), nil
+		}, opts...),
+		gomapper.AddConverter(func(value *TValue) (TMessage, error) {
+			if value == nil {
+				return zero, nil
+			}
+			return wrap(*value), nil
+		}, opts...),
+		gomapper.AddConverter(func(m TMessage) (TValue, error) {
+			if reflect.ValueOf(m).IsNil() {
+				return *new(TValue), nil
+			}
+			return unwrap(m)
+		}, opts...),
+		gomapper.AddConverter(func(m TMessage) (*TValue, error) {
+			if reflect.ValueOf(m).IsNil() {
+				return nil, nil
+			}
+			value, err := unwrap(m)
+			if err != nil {
+				return nil, err
+			}
+			return &value, nil
+		}, opts...),
+	)
+}
+
+// messageFields hides unexported fields and oneofs of messages and adds members of oneofs as virtual fields.
+func messageFields(structType reflect.Type) (gomapper.StructFields, bool) {
+	if !reflect.PointerTo(structType).Implements(messageType) {
+		return gomapper.StructFields{}, false
+	}
+	var fields gomapper.StructFields
+	for i := 0; i < structType.NumField(); i++ {
+		f := structType.Field(i)
+		if !f.IsExported() || f.Tag.Get("protobuf_oneof") != "" {
+			fields.Hidden = append(fields.Hidden, f.Name)
+		}
+	}
+	oneofs := reflect.New(structType).Interface().(proto.Message).ProtoReflect().Descriptor().Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		oneof := oneofs.Get(i)
+		if oneof.IsSynthetic() {
+			// proto3 optional fields are generated as pointer fields
+			continue
+		}
+		for j := 0; j < oneof.Fields().Len(); j++ {
+			if field, ok := oneofField(structType, oneof.Fields().Get(j)); ok {
+				fields.Virtual = append(fields.Virtual, field)
+			}
+		}
+	}
+	return fields, true
+}
+
+// oneofField returns the virtual field of the oneof member, the value is read with the generated getter.
+func oneofField(structType reflect.Type, fd protoreflect.FieldDescriptor) (gomapper.VirtualField, bool) {
+	getter, ok := reflect.PointerTo(structType).MethodByName("Get" + goCamelCase(string(fd.Name())))
+	if !ok || getter.Type.NumIn() != 1 || getter.Type.NumOut() != 1 {
+		return gomapper.VirtualField{}, false
+	}
+	return gomapper.VirtualField{
+		Name: strings.TrimPrefix(getter.Name, "Get"),
+		Type: getter.Type.Out(0),
+		Get: func(structPtr any) (any, bool) {
+			if structPtr.(proto.Message).ProtoReflect().WhichOneof(fd.ContainingOneof()) != fd {
+				return nil, false
+			}
+			return reflect.ValueOf(structPtr).Method(getter.Index).Call(nil)[0].Interface(), true
+		},
+		Set: func(structPtr any, value any) {
+			structPtr.(proto.Message).ProtoReflect().Set(fd, protoValue(value))
+		},
+	}, true
+}
+
+func protoValue(value any) protoreflect.Value {
+	switch v := value.(type) {
+	case proto.Message:
+		return protoreflect.ValueOfMessage(v.ProtoReflect())
+	case protoreflect.Enum:
+		return protoreflect.ValueOfEnum(v.Number())
+	default:
+		return protoreflect.ValueOf(v)
+	}
+}
+
+// goCamelCase returns the Go name of the field like protoc-gen-go, i.e. created_at is CreatedAt.
+func goCamelCase(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(name) && isLower(name[i+1]):
+			// the next word starts with the upper case letter
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(name) && isLower(name[i+1]); i++ {
+				b = append(b, name[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isLower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
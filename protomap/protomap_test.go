@@ -0,0 +1,145 @@
+package protomap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insei/gomapper"
+	"github.com/insei/gomapper/protomap/internal/testpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type User struct {
+	Id             string
+	Name           string
+	CreatedAt      time.Time
+	SessionTimeout time.Duration
+	Nickname       *string
+	Age            int64
+	Verified       *bool
+	Address        *Address
+	Tags           []string
+	Email          string
+	Phone          string
+	Postal         *Address
+	Title          *string
+	Status         string
+	DeletedAt      *time.Time
+}
+
+func TestRegister(t *testing.T) {
+	assert.NoError(t, Register())
+	assert.NoError(t, gomapper.AddConverter(func(source testpb.Status) (string, error) {
+		return source.String(), nil
+	}))
+	assert.NoError(t, gomapper.AddConverter(func(source string) (testpb.Status, error) {
+		return testpb.Status(testpb.Status_value[source]), nil
+	}))
+	assert.NoError(t, gomapper.AutoRouteBidirectional[*testpb.Address, Address]())
+	assert.NoError(t, gomapper.AutoRouteBidirectional[*testpb.User, User]())
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	deletedAt := createdAt.Add(time.Hour)
+	nickname, title, verified := "jo", "Mr", true
+	message := &testpb.User{
+		Id:             "1",
+		Name:           "John",
+		CreatedAt:      timestamppb.New(createdAt),
+		SessionTimeout: durationpb.New(time.Minute),
+		Nickname:       wrapperspb.String(nickname),
+		Age:            wrapperspb.Int64(30),
+		Verified:       wrapperspb.Bool(verified),
+		Address:        &testpb.Address{City: "Berlin", Street: "Main"},
+		Tags:           []string{"a", "b"},
+		Contact:        &testpb.User_Email{Email: "john@example.com"},
+		Title:          &title,
+		State:          &testpb.User_Status{Status: testpb.Status_STATUS_BLOCKED},
+	}
+	user := User{
+		Id:             "1",
+		Name:           "John",
+		CreatedAt:      createdAt,
+		SessionTimeout: time.Minute,
+		Nickname:       &nickname,
+		Age:            30,
+		Verified:       &verified,
+		Address:        &Address{City: "Berlin", Street: "Main"},
+		Tags:           []string{"a", "b"},
+		Email:          "john@example.com",
+		Title:          &title,
+		Status:         "STATUS_BLOCKED",
+	}
+
+	t.Run("Message to struct", func(t *testing.T) {
+		dest := User{}
+		assert.NoError(t, gomapper.Map(message, &dest))
+		assert.Equal(t, user, dest)
+	})
+	t.Run("Struct to message", func(t *testing.T) {
+		dest := &testpb.User{}
+		assert.NoError(t, gomapper.Map(user, dest))
+		assert.True(t, proto.Equal(message, dest), dest.String())
+	})
+	t.Run("Message oneofs", func(t *testing.T) {
+		source := &testpb.User{
+			Contact: &testpb.User_Postal{Postal: &testpb.Address{City: "Paris"}},
+			State:   &testpb.User_DeletedAt{DeletedAt: timestamppb.New(deletedAt)},
+		}
+		dest := User{}
+		assert.NoError(t, gomapper.Map(source, &dest))
+		assert.Equal(t, User{Postal: &Address{City: "Paris"}, DeletedAt: &deletedAt}, dest)
+
+		message := &testpb.User{}
+		assert.NoError(t, gomapper.Map(dest, message))
+		assert.True(t, proto.Equal(source, message), message.String())
+	})
+	t.Run("Unset values", func(t *testing.T) {
+		dest := User{}
+		assert.NoError(t, gomapper.Map(&testpb.User{}, &dest))
+		assert.Equal(t, User{}, dest)
+
+		message := &testpb.User{}
+		assert.NoError(t, gomapper.Map(User{}, message))
+		assert.True(t, proto.Equal(&testpb.User{}, message), message.String())
+	})
+	t.Run("Invalid timestamp", func(t *testing.T) {
+		dest := User{}
+		err := gomapper.Map(&testpb.User{CreatedAt: &timestamppb.Timestamp{Nanos: -1}}, &dest)
+		assert.ErrorContains(t, err, "out-of-range nanos")
+	})
+	t.Run("Explain", func(t *testing.T) {
+		explanation, err := gomapper.ExplainRoute[User, *testpb.User]()
+		assert.NoError(t, err)
+		var paths []string
+		for _, f := range explanation.Fields {
+			paths = append(paths, f.DestPath)
+			assert.NotEqual(t, gomapper.FieldMappingUnmapped, f.Mapping, f.DestPath)
+			assert.NotEqual(t, gomapper.FieldMappingTypeMismatch, f.Mapping, f.DestPath)
+		}
+		assert.Equal(t, []string{"Id", "Name", "CreatedAt", "SessionTimeout", "Nickname", "Age", "Verified", "Address",
+			"Tags", "Title", "Email", "Phone", "Postal", "Status", "DeletedAt"}, paths)
+	})
+}
+
+func TestGoCamelCase(t *testing.T) {
+	cases := map[string]string{
+		"email":      "Email",
+		"created_at": "CreatedAt",
+		"_private":   "XPrivate",
+		"ipv4_addr":  "Ipv4Addr",
+		"field_1":    "Field_1",
+		"oneOf":      "OneOf",
+	}
+	for name, want := range cases {
+		assert.Equal(t, want, goCamelCase(name), name)
+	}
+}
@@ -3,113 +3,720 @@ package gomapper
 import (
 	"fmt"
 	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-var routes = map[reflect.Type]map[reflect.Type]func(source interface{}, dest interface{}) error{}
+// route is the registered mapping function with its description.
+type route struct {
+	mapFunc func(s *mapState, source interface{}, dest interface{}) error
+	// typedMapFunc is the mapping function with types of the route, it's called by MapAs
+	typedMapFunc any
+	info         RouteInfo
+	// auto is the resolved configuration of auto routes
+	auto *autoRoute
+	// order is the registration order of the route
+	order int
+}
+
+var (
+	routes     = map[reflect.Type]map[reflect.Type]*route{}
+	routeOrder = 0
+	// interfaceSources are source types of routes from interfaces
+	interfaceSources = map[reflect.Type]struct{}{}
+)
+
+// RouteKind is the kind of the registered route.
+type RouteKind int
+
+const (
+	// RouteKindManual is the route registered with AddRoute.
+	RouteKindManual RouteKind = iota
+	// RouteKindAuto is the route registered with AutoRoute.
+	RouteKindAuto
+	// RouteKindSlice is the route between slices generated for the route between slice elements.
+	RouteKindSlice
+	// RouteKindConverter is the route registered with AddConverter.
+	RouteKindConverter
+	// RouteKindPolymorphic is the route between interfaces registered with AddPolymorphicRoute.
+	RouteKindPolymorphic
+	// RouteKindMap is the route between the struct and the map registered with AutoMapRoute or AutoValuesRoute.
+	RouteKindMap
+)
 
-func addSliceRoute[TSliceSource any, TSliceDest any](sliceMapFunc func(sourceSlice TSliceSource, destSlice TSliceDest) error) {
-	funcConverted := func(source any, dest any) error {
-		return sliceMapFunc(source.(TSliceSource), dest.(TSliceDest))
+func (k RouteKind) String() string {
+	switch k {
+	case RouteKindManual:
+		return "manual"
+	case RouteKindAuto:
+		return "auto"
+	case RouteKindSlice:
+		return "slice"
+	case RouteKindConverter:
+		return "converter"
+	case RouteKindPolymorphic:
+		return "polymorphic"
+	case RouteKindMap:
+		return "map"
+	default:
+		return "RouteKind(" + strconv.Itoa(int(k)) + ")"
 	}
-	sourceSlice := *new(TSliceSource)
-	destSlice := *new(TSliceDest)
-	var route map[reflect.Type]func(source interface{}, dest interface{}) error
-	var ok bool
-	if route, ok = routes[reflect.TypeOf(sourceSlice)]; !ok {
-		route = map[reflect.Type]func(source interface{}, dest interface{}) error{}
-		routes[reflect.TypeOf(sourceSlice)] = route
+}
+
+// RouteInfo describes the registered route.
+type RouteInfo struct {
+	Source reflect.Type
+	Dest   reflect.Type
+	Kind   RouteKind
+	// CallSite is the file:line of the route registration call,
+	// slice routes have the call site of the route between their elements.
+	CallSite string
+	// Fields are the resolved field pairs of auto routes.
+	Fields []RouteField
+	// SkippedSourceFields are paths of source fields skipped by auto routes.
+	SkippedSourceFields []string
+	// SkippedDestFields are paths of destination fields skipped by auto routes.
+	SkippedDestFields []string
+	// NotInverted are options of the forward auto route, which can't be inverted and aren't applied
+	// to this reverse route.
+	NotInverted []string
+}
+
+// FieldMatch describes how the destination field of the auto route is matched with the source field.
+type FieldMatch int
+
+const (
+	// FieldMatchName is the field matched by the same path.
+	FieldMatchName FieldMatch = iota
+	// FieldMatchRename is the field renamed with WithFieldRename.
+	FieldMatchRename
+	// FieldMatchFlatten is the nested source field matched with the flattened destination field path.
+	FieldMatchFlatten
+	// FieldMatchUnflatten is the source field matched with the nested destination field by the reverse route.
+	FieldMatchUnflatten
+	// FieldMatchBase is the embedded struct mapped with the base route included with IncludeBase.
+	FieldMatchBase
+)
+
+func (m FieldMatch) String() string {
+	switch m {
+	case FieldMatchName:
+		return "name"
+	case FieldMatchRename:
+		return "rename"
+	case FieldMatchFlatten:
+		return "flatten"
+	case FieldMatchUnflatten:
+		return "unflatten"
+	case FieldMatchBase:
+		return "base"
+	default:
+		return "FieldMatch(" + strconv.Itoa(int(m)) + ")"
 	}
-	route[reflect.TypeOf(destSlice)] = funcConverted
 }
 
-func addSliceRoutes[TSource, TDest any]() {
-	//source slice is a value, dest slice is a pointer
-	addSliceRoute(func(sourceSlice []TSource, pointerDestSlice *[]TDest) error {
-		if len(sourceSlice) == 0 {
-			*pointerDestSlice = make([]TDest, 0)
+// RouteField is the field pair of the auto route.
+type RouteField struct {
+	SourcePath string
+	DestPath   string
+	Match      FieldMatch
+	// Conditional is true when the field is mapped only when conditions are met.
+	Conditional bool
+}
+
+// Routes returns descriptions of all registered routes in the registration order.
+func Routes() []RouteInfo {
+	sorted := sortedRoutes()
+	infos := make([]RouteInfo, 0, len(sorted))
+	for _, r := range sorted {
+		infos = append(infos, r.info)
+	}
+	return infos
+}
+
+// sortedRoutes returns all registered routes in the registration order.
+func sortedRoutes() []*route {
+	all := make([]*route, 0, len(routes))
+	for _, sourceRoutes := range routes {
+		for _, r := range sourceRoutes {
+			all = append(all, r)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].order < all[j].order
+	})
+	return all
+}
+
+// HasRoute reports whether Map can find the route for the source of TSource type to TDest.
+func HasRoute[TSource, TDest any]() bool {
+	r, _ := findRoute(reflect.TypeOf((*TSource)(nil)).Elem(), reflect.TypeOf((*TDest)(nil)))
+	return r != nil
+}
+
+// findRoute returns the route for the source type, the route is searched in the order:
+//   - the route from the source type;
+//   - the route from the dereferenced source type, if the source type is a pointer;
+//   - the route from the pointer to the source type;
+//   - the route to the pointer to the destination type, which writes through the destination pointer;
+//   - the route from the interface implemented by the source type or the pointer to it, the route from
+//     the most specific interface is used, i.e. the interface which implements other matched interfaces;
+//   - the built-in route between the optional wrapper type and its value, the pointer to the value or another wrapper.
+//   - the route between slices mapping elements with routes found for their types, if slice types have no route.
+//
+// If there are several most specific interfaces, an ambiguity error is returned.
+// Results, including missing routes, are cached until routes or wrapper types are changed.
+func findRoute(sourceType, destPtrType reflect.Type) (*route, error) {
+	key := routeKey{source: sourceType, destPtr: destPtrType}
+	if cached, ok := resolvedRoutes.Load(key); ok {
+		resolved := cached.(resolvedRoute)
+		return resolved.route, resolved.err
+	}
+	r, err := resolveRoute(sourceType, destPtrType)
+	resolvedRoutes.Store(key, resolvedRoute{route: r, err: err})
+	return r, err
+}
+
+type routeKey struct {
+	source  reflect.Type
+	destPtr reflect.Type
+}
+
+// resolvedRoute is the result of findRoute, the route is nil when it's not found.
+type resolvedRoute struct {
+	route *route
+	err   error
+}
+
+// resolvedRoutes caches results of findRoute by routeKey.
+var resolvedRoutes sync.Map
+
+// clearResolvedRoutes clears caches of routes resolved by findRoute and MapAs.
+func clearResolvedRoutes() {
+	for _, cache := range []*sync.Map{&resolvedRoutes, &typedRoutes} {
+		cache.Range(func(key, _ any) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+}
+
+func resolveRoute(sourceType, destPtrType reflect.Type) (*route, error) {
+	derefType := sourceType
+	if derefType.Kind() == reflect.Ptr {
+		derefType = derefType.Elem()
+	}
+	sourceTypes := []reflect.Type{sourceType, derefType, reflect.PointerTo(derefType)}
+	for _, t := range sourceTypes {
+		if r, ok := routes[t][destPtrType]; ok {
+			return r, nil
+		}
+	}
+	for _, t := range sourceTypes {
+		if r, ok := routes[t][reflect.PointerTo(destPtrType)]; ok {
+			return newPointedDestRoute(r, destPtrType), nil
 		}
-		for _, source := range sourceSlice {
-			dest, err := MapTo[TDest](source)
-			if err != nil {
+	}
+	r, err := findInterfaceRoute(derefType, destPtrType)
+	if r != nil || err != nil {
+		return r, err
+	}
+	if r = findWrapperRoute(sourceType, destPtrType); r != nil {
+		return r, nil
+	}
+	return findElementsRoute(derefType, destPtrType)
+}
+
+// newPointedDestRoute returns the route to the value pointed by the destination with the route registered
+// to the pointer type, i.e. to *pb.User, the route writes through the destination pointer.
+func newPointedDestRoute(r *route, destPtrType reflect.Type) *route {
+	return &route{
+		mapFunc: func(s *mapState, source any, dest any) error {
+			destPtr := reflect.ValueOf(dest)
+			ptr := reflect.New(destPtrType)
+			ptr.Elem().Set(destPtr)
+			if err := r.mapFunc(s, source, ptr.Interface()); err != nil {
 				return err
 			}
-			*pointerDestSlice = append(*pointerDestSlice, dest)
+			// the route could set another pointer
+			switch {
+			case ptr.Elem().IsNil():
+				destPtr.Elem().SetZero()
+			case ptr.Elem().Pointer() != destPtr.Pointer():
+				destPtr.Elem().Set(ptr.Elem().Elem())
+			}
+			return nil
+		},
+		info: r.info,
+	}
+}
+
+// findElementsRoute returns the route between slices without the registered slice route, which maps elements
+// with routes resolved for the element type, i.e. the route from the interface implemented by elements.
+func findElementsRoute(sourceType, destPtrType reflect.Type) (*route, error) {
+	destType := destPtrType.Elem()
+	if sourceType.Kind() != reflect.Slice || destType.Kind() != reflect.Slice {
+		return nil, nil
+	}
+	destElemType := destType.Elem()
+	destElemPtrType := reflect.PointerTo(destElemType)
+	if destElemType.Kind() == reflect.Ptr {
+		destElemPtrType = destElemType
+	}
+	r, err := findRoute(sourceType.Elem(), destElemPtrType)
+	if r == nil {
+		return nil, err
+	}
+	return &route{
+		mapFunc: func(s *mapState, source any, dest any) error {
+			return mapElementValues(s, reflect.ValueOf(source), reflect.ValueOf(dest).Elem())
+		},
+		info: RouteInfo{Source: sourceType, Dest: destElemType, Kind: RouteKindSlice, CallSite: r.info.CallSite},
+	}, nil
+}
+
+// mapElementValues appends elements of the source slice mapped to elements of the destination slice, nil pointer
+// elements are mapped to nil pointers like in generated slice routes.
+func mapElementValues(s *mapState, sourceSlice, destSlice reflect.Value) error {
+	n := sourceSlice.Len()
+	if n == 0 {
+		destSlice.Set(reflect.MakeSlice(destSlice.Type(), 0, 0))
+		return nil
+	}
+	start := destSlice.Len()
+	grown := reflect.AppendSlice(destSlice, reflect.MakeSlice(destSlice.Type(), n, n))
+	defer destSlice.Set(grown)
+	for i := 0; i < n; i++ {
+		source, dest := sourceSlice.Index(i), grown.Index(start+i)
+		err := s.mapElement(i, dest.Addr().Interface(), func() error {
+			if dest.Kind() != reflect.Ptr {
+				return s.mapValue(source.Interface(), dest.Addr().Interface())
+			}
+			if (source.Kind() == reflect.Ptr || source.Kind() == reflect.Interface) && source.IsNil() {
+				return nil
+			}
+			if source.Kind() != reflect.Ptr {
+				dest.Set(reflect.New(dest.Type().Elem()))
+				return s.mapValue(source.Interface(), dest.Interface())
+			}
+			// tracked pointers are resolved by the source pointer
+			destPtr, err := s.mapPointer(source.Interface(), dest.Type().Elem())
+			if err == nil {
+				dest.Set(destPtr)
+			}
+			return err
+		})
+		if err != nil {
+			return err
 		}
+	}
+	return nil
+}
+
+func findInterfaceRoute(sourceType, destPtrType reflect.Type) (*route, error) {
+	var candidates []*route
+	for t := range interfaceSources {
+		r, ok := routes[t][destPtrType]
+		if !ok {
+			continue
+		}
+		if sourceType.Implements(t) || reflect.PointerTo(sourceType).Implements(t) {
+			candidates = append(candidates, r)
+		}
+	}
+	var mostSpecific []*route
+	for _, c := range candidates {
+		if !hasMoreSpecificRoute(c, candidates) {
+			mostSpecific = append(mostSpecific, c)
+		}
+	}
+	if len(mostSpecific) == 0 {
+		return nil, nil
+	}
+	if len(mostSpecific) == 1 {
+		return mostSpecific[0], nil
+	}
+	names := make([]string, 0, len(mostSpecific))
+	for _, r := range mostSpecific {
+		names = append(names, getTypeNameRecursive(r.info.Source, ""))
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("ambiguous routes for type %s to type %s from interfaces: %s",
+		getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destPtrType.Elem(), ""), strings.Join(names, ", "))
+}
+
+// hasMoreSpecificRoute reports whether any of the routes has the source interface, which implements
+// the source interface of the route, but is not implemented by it.
+func hasMoreSpecificRoute(r *route, routes []*route) bool {
+	for _, other := range routes {
+		if other.info.Source.Implements(r.info.Source) && !r.info.Source.Implements(other.info.Source) {
+			return true
+		}
+	}
+	return false
+}
+
+func getCallSite(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	return file + ":" + strconv.Itoa(line)
+}
+
+// checkRoute returns an error if the route is already registered and can't be replaced,
+// generated slice routes are always replaced.
+func checkRoute(sourceType, destPtrType reflect.Type, replace bool) error {
+	existing, ok := routes[sourceType][destPtrType]
+	if !ok || replace || existing.info.Kind == RouteKindSlice {
 		return nil
+	}
+	return fmt.Errorf("route already registered for type %s to type %s at %s, use WithReplace option to replace it",
+		getTypeNameRecursive(sourceType, ""), getTypeNameRecursive(destPtrType.Elem(), ""), existing.info.CallSite)
+}
+
+func setRoute(sourceType, destPtrType reflect.Type, r *route) {
+	sourceRoutes, ok := routes[sourceType]
+	if !ok {
+		sourceRoutes = map[reflect.Type]*route{}
+		routes[sourceType] = sourceRoutes
+	}
+	routeOrder++
+	r.order = routeOrder
+	sourceRoutes[destPtrType] = r
+	if sourceType.Kind() == reflect.Interface {
+		interfaceSources[sourceType] = struct{}{}
+	}
+	clearResolvedRoutes()
+}
+
+func addSliceRoute[TSliceSource any, TSliceDest any](callSite string, sliceMapFunc func(s *mapState, sourceSlice TSliceSource, destSlice TSliceDest) error) {
+	funcConverted := func(s *mapState, source any, dest any) error {
+		return sliceMapFunc(s, source.(TSliceSource), dest.(TSliceDest))
+	}
+	sourceSliceType := reflect.TypeOf((*TSliceSource)(nil)).Elem()
+	destSliceType := reflect.TypeOf((*TSliceDest)(nil)).Elem()
+	if existing, ok := routes[sourceSliceType][destSliceType]; ok && existing.info.Kind != RouteKindSlice {
+		// explicitly registered routes between slices are not replaced by generated ones
+		return
+	}
+	setRoute(sourceSliceType, destSliceType, &route{
+		mapFunc: funcConverted,
+		info: RouteInfo{
+			Source:   sourceSliceType,
+			Dest:     destSliceType.Elem(),
+			Kind:     RouteKindSlice,
+			CallSite: callSite,
+		},
+	})
+}
+
+func addSliceRoutes[TSource, TDest any](callSite string) {
+	sourceType := reflect.TypeOf((*TSource)(nil)).Elem()
+	destPtrType := reflect.TypeOf((*TDest)(nil))
+	//source slice is a value, dest slice is a pointer
+	addSliceRoute(callSite, func(s *mapState, sourceSlice []TSource, pointerDestSlice *[]TDest) error {
+		if len(sourceSlice) == 0 {
+			*pointerDestSlice = make([]TDest, 0)
+			return nil
+		}
+		mapFunc, err := elementRoute(sourceType, destPtrType)
+		if err != nil {
+			return err
+		}
+		destSlice, grown := growSlice(*pointerDestSlice, len(sourceSlice))
+		err = mapElements(s, sourceSlice, grown, func(s *mapState, source TSource, dest *TDest) error {
+			return mapFunc(s, source, dest)
+		})
+		*pointerDestSlice = destSlice
+		return err
 	})
 	//source slice is a value, dest slice is a pointer with pointer elements
-	addSliceRoute(func(sourceSlice []TSource, pointerDestSlice *[]*TDest) error {
+	addSliceRoute(callSite, func(s *mapState, sourceSlice []TSource, pointerDestSlice *[]*TDest) error {
 		if len(sourceSlice) == 0 {
 			*pointerDestSlice = make([]*TDest, 0)
+			return nil
 		}
-		for _, source := range sourceSlice {
-			dest, err := MapTo[TDest](source)
-			if err != nil {
-				return err
-			}
-			*pointerDestSlice = append(*pointerDestSlice, &dest)
+		mapFunc, err := elementRoute(sourceType, destPtrType)
+		if err != nil {
+			return err
 		}
-		return nil
+		destSlice, grown := growSlice(*pointerDestSlice, len(sourceSlice))
+		err = mapElements(s, sourceSlice, grown, func(s *mapState, source TSource, dest **TDest) error {
+			*dest = new(TDest)
+			return mapFunc(s, source, *dest)
+		})
+		*pointerDestSlice = destSlice
+		return err
 	})
 	//source slice is a value, dest slice is a pointer
-	addSliceRoute(func(sourceSlice []*TSource, pointerDestSlice *[]TDest) error {
+	addSliceRoute(callSite, func(s *mapState, sourceSlice []*TSource, pointerDestSlice *[]TDest) error {
 		if len(sourceSlice) == 0 {
 			*pointerDestSlice = make([]TDest, 0)
+			return nil
 		}
-		for _, source := range sourceSlice {
-			dest, err := MapTo[TDest](source)
-			if err != nil {
-				return err
-			}
-			*pointerDestSlice = append(*pointerDestSlice, dest)
+		mapFunc, err := elementRoute(reflect.PointerTo(sourceType), destPtrType)
+		if err != nil {
+			return err
 		}
-		return nil
+		destSlice, grown := growSlice(*pointerDestSlice, len(sourceSlice))
+		err = mapElements(s, sourceSlice, grown, func(s *mapState, source *TSource, dest *TDest) error {
+			if source == nil {
+				return validateSource(source)
+			}
+			return mapFunc(s, source, dest)
+		})
+		*pointerDestSlice = destSlice
+		return err
 	})
-	addSliceRoute(func(sourceSlice []*TSource, pointerDestSlice *[]*TDest) error {
+	//pointer elements are mapped to pointer elements, nil elements stay nil
+	addSliceRoute(callSite, func(s *mapState, sourceSlice []*TSource, pointerDestSlice *[]*TDest) error {
 		if len(sourceSlice) == 0 {
 			*pointerDestSlice = make([]*TDest, 0)
+			return nil
 		}
-		for _, source := range sourceSlice {
-			dest, err := MapTo[TDest](source)
-			if err != nil {
+		mapFunc, err := elementRoute(reflect.PointerTo(sourceType), destPtrType)
+		if err != nil {
+			return err
+		}
+		destSlice, grown := growSlice(*pointerDestSlice, len(sourceSlice))
+		err = mapElements(s, sourceSlice, grown, func(s *mapState, source *TSource, dest **TDest) (err error) {
+			if source == nil {
+				return nil
+			}
+			if s.refs != nil {
+				// tracked pointers are resolved by the source pointer
+				*dest, err = mapPointerTo[TDest](s, source)
 				return err
 			}
-			*pointerDestSlice = append(*pointerDestSlice, &dest)
-		}
-		return nil
+			*dest = new(TDest)
+			return mapFunc(s, source, *dest)
+		})
+		*pointerDestSlice = destSlice
+		return err
 	})
 }
 
-func addRoute[TSource, TDest any | []any](mapFunc func(source TSource, dest *TDest) error) error {
-	source := *new(TSource)
+// elementRoute resolves the route for elements of the slice once per slice. Elements of interface types are mapped
+// with routes resolved by their dynamic types.
+func elementRoute(sourceType, destPtrType reflect.Type) (func(s *mapState, source any, dest any) error, error) {
+	if sourceType.Kind() == reflect.Interface || sourceType.Kind() == reflect.Ptr && sourceType.Elem().Kind() == reflect.Ptr {
+		return func(s *mapState, source any, dest any) error {
+			return s.mapValue(source, dest)
+		}, nil
+	}
+	r, err := findRoute(sourceType, destPtrType)
+	if err != nil {
+		return nil, err
+	}
+	derefType := sourceType
+	if derefType.Kind() == reflect.Ptr {
+		derefType = derefType.Elem()
+	}
+	if r == nil {
+		return nil, fmt.Errorf("route not found for type %s to type %s",
+			getTypeNameRecursive(derefType, ""), getTypeNameRecursive(destPtrType, ""))
+	}
+	if r.info.Source == sourceType || r.info.Source.Kind() == reflect.Interface {
+		return r.mapFunc, nil
+	}
+	return func(s *mapState, source any, dest any) error {
+		return r.mapFunc(s, prepareSource(source), dest)
+	}, nil
+}
+
+// growSlice appends n zero elements to the slice, returns the slice and appended elements.
+func growSlice[T any](slice []T, n int) ([]T, []T) {
+	slice = append(slice, make([]T, n)...)
+	return slice, slice[len(slice)-n:]
+}
+
+// mapElements maps source elements to destination elements with the same index, elements of the slice mapped
+// with the parallelism are mapped by workers, each with its own state. Mapping stops at the first failed element.
+func mapElements[TSource, TDest any](s *mapState, sourceSlice []TSource, destSlice []TDest,
+	mapFunc func(s *mapState, source TSource, dest *TDest) error) error {
+	if s.parallelism > 1 && len(sourceSlice) > 1 {
+		return mapElementsParallel(s, sourceSlice, destSlice, mapFunc)
+	}
+	for i := range sourceSlice {
+		if err := s.mapElement(i, &destSlice[i], func() error { return mapFunc(s, sourceSlice[i], &destSlice[i]) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapElementsParallel maps chunks of elements by workers. Workers don't start elements past the first failed one,
+// so the error or the panic of the same element as in the sequential mapping is returned or raised again.
+func mapElementsParallel[TSource, TDest any](s *mapState, sourceSlice []TSource, destSlice []TDest,
+	mapFunc func(s *mapState, source TSource, dest *TDest) error) error {
+	workers := min(s.parallelism, len(sourceSlice))
+	errs := make([]error, workers)
+	panics := make([]*workerPanic, workers)
+	// failed is the index of the first failed element, elements before it are mapped by all workers
+	var failed atomic.Int64
+	failed.Store(int64(len(sourceSlice)))
+	fail := func(i int) {
+		for current := failed.Load(); int64(i) < current && !failed.CompareAndSwap(current, int64(i)); {
+			current = failed.Load()
+		}
+	}
+	chunk := (len(sourceSlice) + workers - 1) / workers
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		from, to := w*chunk, min((w+1)*chunk, len(sourceSlice))
+		if from >= to {
+			break
+		}
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			i := from
+			defer func() {
+				// panics are raised again in the goroutine of the caller with the stack of the worker
+				if p := recover(); p != nil {
+					panics[w] = &workerPanic{value: p, stack: debug.Stack()}
+					fail(i)
+				}
+			}()
+			ws := s.fork()
+			for ; i < to && int64(i) < failed.Load(); i++ {
+				if err := ws.mapElement(i, &destSlice[i], func() error { return mapFunc(ws, sourceSlice[i], &destSlice[i]) }); err != nil {
+					errs[w] = err
+					fail(i)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	// chunks are ordered, so the first failed worker has the first failed element
+	for w := range panics {
+		if panics[w] != nil {
+			panic(panics[w])
+		}
+		if errs[w] != nil {
+			return errs[w]
+		}
+	}
+	return nil
+}
+
+// workerPanic is the panic in the worker raised again in the goroutine of the caller, it keeps the stack
+// of the worker, which is lost otherwise.
+type workerPanic struct {
+	value any
+	stack []byte
+}
+
+func (p *workerPanic) Error() string {
+	return fmt.Sprintf("%v [recovered in the worker]\n\n%s", p.value, p.stack)
+}
+
+// Unwrap returns the panic value of the worker, if it's an error.
+func (p *workerPanic) Unwrap() error {
+	err, _ := p.value.(error)
+	return err
+}
+
+func addRoute[TSource, TDest any | []any](mapFunc func(s *mapState, source TSource, dest *TDest) error, r *route, replace bool) error {
 	dest := *new(TDest)
 
-	sourceValueOf := reflect.ValueOf(source)
-	if sourceValueOf.Kind() == reflect.Ptr {
-		return fmt.Errorf("source type can't be reference type, route: %s -> %s", getTypeName(source), getTypeName(dest))
+	sourceType := reflect.TypeOf((*TSource)(nil)).Elem()
+	if sourceType.Kind() == reflect.Ptr && sourceType.Elem().Kind() == reflect.Ptr {
+		return fmt.Errorf("source type can't be a pointer to pointer, route: %s -> %s",
+			getTypeNameRecursive(sourceType, ""), getTypeName(dest))
 	}
-	var route map[reflect.Type]func(source interface{}, dest interface{}) error
-	route, ok := routes[reflect.TypeOf(source)]
-	if !ok {
-		route = map[reflect.Type]func(source interface{}, dest interface{}) error{}
-		routes[reflect.TypeOf(source)] = route
+	if err := checkRoute(sourceType, reflect.TypeOf(&dest), replace); err != nil {
+		return err
 	}
-	funcConverted := func(source any, dest any) error {
+	funcConverted := func(state *mapState, source any, dest any) (err error) {
+		if state.recoverPanics {
+			defer state.recoverPanic(r, &err)
+		}
 		sourceValueOf := reflect.ValueOf(source)
 		for sourceValueOf.Kind() == reflect.Ptr {
 			if sourceValueOf.IsNil() {
 				return nil
 			}
+			if s, ok := sourceValueOf.Interface().(TSource); ok {
+				return mapFunc(state, s, dest.(*TDest))
+			}
 			sourceValueOf = sourceValueOf.Elem()
 		}
-		return mapFunc(sourceValueOf.Interface().(TSource), dest.(*TDest))
+		if s, ok := sourceValueOf.Interface().(TSource); ok {
+			return mapFunc(state, s, dest.(*TDest))
+		}
+		// the source type is a pointer or the interface implemented with pointer receivers
+		sourcePtr := reflect.New(sourceValueOf.Type())
+		sourcePtr.Elem().Set(sourceValueOf)
+		return mapFunc(state, sourcePtr.Interface().(TSource), dest.(*TDest))
 	}
-	route[reflect.TypeOf(&dest)] = funcConverted
+	r.mapFunc = funcConverted
+	r.typedMapFunc = mapFunc
+	r.info.Source = sourceType
+	r.info.Dest = reflect.TypeOf((*TDest)(nil)).Elem()
+	setRoute(r.info.Source, reflect.TypeOf(&dest), r)
 	// source is value, dest is ptr - its important
-	addSliceRoutes[TSource, TDest]()
+	addSliceRoutes[TSource, TDest](r.info.CallSite)
 	return nil
 }
 
-func AddRoute[TSource, TDest any | []any](mapFunc func(source TSource, dest *TDest) error) error {
-	return addRoute[TSource, TDest](mapFunc)
+// AddRoute registers the route with the mapping function and routes between slices of TSource and TDest.
+// Registration of the already registered route returns an error, unless WithReplace option is used.
+func AddRoute[TSource, TDest any | []any](mapFunc func(source TSource, dest *TDest) error, opts ...RouteOption) error {
+	opt := applyRouteOptions(opts)
+	mapFuncWithState := func(_ *mapState, source TSource, dest *TDest) error {
+		return mapFunc(source, dest)
+	}
+	return addRoute[TSource, TDest](mapFuncWithState, &route{info: RouteInfo{Kind: RouteKindManual, CallSite: getCallSite(1)}}, opt.Replace)
+}
+
+// AddConverter registers the route which converts the source value to the destination value.
+// Converters are used by auto routes for fields of different types, like any other route.
+func AddConverter[TSource, TDest any](convert func(source TSource) (TDest, error), opts ...RouteOption) error {
+	mapFunc := func(_ *mapState, source TSource, dest *TDest) error {
+		value, err := convert(source)
+		if err != nil {
+			return err
+		}
+		*dest = value
+		return nil
+	}
+	opt := applyRouteOptions(opts)
+	return addRoute[TSource, TDest](mapFunc, &route{info: RouteInfo{Kind: RouteKindConverter, CallSite: getCallSite(1)}}, opt.Replace)
+}
+
+// RemoveRoute removes the route from TSource to TDest and routes between slices generated for it,
+// reports whether the route was registered.
+func RemoveRoute[TSource, TDest any]() bool {
+	sourceType := reflect.TypeOf((*TSource)(nil)).Elem()
+	destPtrType := reflect.TypeOf((*TDest)(nil))
+	if _, ok := routes[sourceType][destPtrType]; !ok {
+		return false
+	}
+	deleteRoute(sourceType, destPtrType)
+	for _, sourceSliceType := range []reflect.Type{reflect.SliceOf(sourceType), reflect.SliceOf(reflect.PointerTo(sourceType))} {
+		for _, destSliceType := range []reflect.Type{reflect.SliceOf(destPtrType.Elem()), reflect.SliceOf(destPtrType)} {
+			destSlicePtrType := reflect.PointerTo(destSliceType)
+			if r, ok := routes[sourceSliceType][destSlicePtrType]; ok && r.info.Kind == RouteKindSlice {
+				deleteRoute(sourceSliceType, destSlicePtrType)
+			}
+		}
+	}
+	return true
+}
+
+func deleteRoute(sourceType, destPtrType reflect.Type) {
+	delete(routes[sourceType], destPtrType)
+	if len(routes[sourceType]) == 0 {
+		delete(routes, sourceType)
+		delete(interfaceSources, sourceType)
+	}
+	clearResolvedRoutes()
 }
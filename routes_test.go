@@ -0,0 +1,131 @@
+package gomapper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RoutesTestSource struct {
+	Name     string
+	Password string
+	Address  ReverseAddress
+}
+
+type RoutesTestDest struct {
+	FullName    string
+	Password    string
+	AddressCity string
+}
+
+type RoutesManualTestDest struct {
+	Name string
+}
+
+func findRouteInfo(source, dest reflect.Type) (RouteInfo, bool) {
+	for _, info := range Routes() {
+		if info.Source == source && info.Dest == dest {
+			return info, true
+		}
+	}
+	return RouteInfo{}, false
+}
+
+func TestRoutes(t *testing.T) {
+	sourceType := reflect.TypeOf(RoutesTestSource{})
+	err := AddRoute[RoutesTestSource, RoutesManualTestDest](func(source RoutesTestSource, dest *RoutesManualTestDest) error {
+		dest.Name = source.Name
+		return nil
+	})
+	assert.NoError(t, err)
+	t.Run("Manual route", func(t *testing.T) {
+		info, ok := findRouteInfo(sourceType, reflect.TypeOf(RoutesManualTestDest{}))
+		assert.True(t, ok)
+		assert.Equal(t, RouteKindManual, info.Kind)
+		assert.Contains(t, info.CallSite, "routes_test.go:")
+		assert.Empty(t, info.Fields)
+	})
+	t.Run("Slice routes", func(t *testing.T) {
+		for _, pair := range [][2]reflect.Type{
+			{reflect.TypeOf([]RoutesTestSource{}), reflect.TypeOf([]RoutesManualTestDest{})},
+			{reflect.TypeOf([]RoutesTestSource{}), reflect.TypeOf([]*RoutesManualTestDest{})},
+			{reflect.TypeOf([]*RoutesTestSource{}), reflect.TypeOf([]RoutesManualTestDest{})},
+			{reflect.TypeOf([]*RoutesTestSource{}), reflect.TypeOf([]*RoutesManualTestDest{})},
+		} {
+			info, ok := findRouteInfo(pair[0], pair[1])
+			assert.True(t, ok)
+			assert.Equal(t, RouteKindSlice, info.Kind)
+			assert.Contains(t, info.CallSite, "routes_test.go:")
+		}
+	})
+	t.Run("Auto route fields", func(t *testing.T) {
+		err := AutoRoute[RoutesTestSource, RoutesTestDest](
+			WithFieldRename(func(source *RoutesTestSource) any {
+				return &source.Name
+			}, func(dest *RoutesTestDest) any {
+				return &dest.FullName
+			}),
+			WithFieldSkip(func(source *RoutesTestSource) any {
+				return &source.Password
+			}),
+		)
+		assert.NoError(t, err)
+		info, ok := findRouteInfo(sourceType, reflect.TypeOf(RoutesTestDest{}))
+		assert.True(t, ok)
+		assert.Equal(t, RouteKindAuto, info.Kind)
+		assert.Contains(t, info.CallSite, "routes_test.go:")
+		assert.Equal(t, []RouteField{
+			{SourcePath: "Name", DestPath: "FullName", Match: FieldMatchRename},
+			{SourcePath: "Address.City", DestPath: "AddressCity", Match: FieldMatchFlatten},
+		}, info.Fields)
+		assert.Equal(t, []string{"Password"}, info.SkippedSourceFields)
+		assert.Empty(t, info.SkippedDestFields)
+	})
+	t.Run("Converter", func(t *testing.T) {
+		err := AddConverter(func(source time.Duration) (string, error) {
+			return source.String(), nil
+		}, WithReplace())
+		assert.NoError(t, err)
+		info, ok := findRouteInfo(reflect.TypeOf(time.Duration(0)), reflect.TypeOf(""))
+		assert.True(t, ok)
+		assert.Equal(t, RouteKindConverter, info.Kind)
+		dest, err := MapTo[string](time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, "1s", dest)
+	})
+}
+
+func TestHasRoute(t *testing.T) {
+	_ = AddRoute[TestingStructSource, TestingStructDest](converterFunc)
+	assert.True(t, HasRoute[TestingStructSource, TestingStructDest]())
+	assert.True(t, HasRoute[*TestingStructSource, TestingStructDest]())
+	assert.True(t, HasRoute[[]TestingStructSource, []*TestingStructDest]())
+	assert.False(t, HasRoute[TestingStructDest, TestingStructSource]())
+}
+
+type ResolvedRouteSource struct {
+	Name string
+}
+
+type ResolvedRouteDest struct {
+	Name string
+}
+
+func TestFindRouteCache(t *testing.T) {
+	assert.False(t, HasRoute[ResolvedRouteSource, ResolvedRouteDest]())
+	assert.NoError(t, AddRoute[ResolvedRouteSource, ResolvedRouteDest](func(source ResolvedRouteSource, dest *ResolvedRouteDest) error {
+		dest.Name = source.Name
+		return nil
+	}))
+	assert.True(t, HasRoute[*ResolvedRouteSource, ResolvedRouteDest]())
+	dest, err := MapTo[ResolvedRouteDest](ResolvedRouteSource{Name: "Test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", dest.Name)
+
+	assert.True(t, RemoveRoute[ResolvedRouteSource, ResolvedRouteDest]())
+	assert.False(t, HasRoute[*ResolvedRouteSource, ResolvedRouteDest]())
+	_, err = MapTo[ResolvedRouteDest](ResolvedRouteSource{Name: "Test"})
+	assert.ErrorContains(t, err, "route not found")
+}
@@ -0,0 +1,135 @@
+package gomapper
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// rowScanner scans rows to fields of T matched with columns.
+type rowScanner[T any] struct {
+	route *structMapRoute
+	// fields are fields of columns, nil for columns without fields
+	fields []*keyedField
+}
+
+func newRowScanner[T any](rows *sql.Rows, opts []Option) (*rowScanner[T], error) {
+	routeName := fmt.Sprintf("*database/sql.Rows -> %s", getTypeName(*new(T)))
+	r, _, err := newStructMapRouteFor[T](routeName, opts)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	return &rowScanner[T]{route: r, fields: matchColumns(r.fromMap, columns)}, nil
+}
+
+// matchColumns returns fields matched with columns, nil for columns without fields. Nested fields of matched
+// fields are not matched.
+func matchColumns(fields []keyedField, columns []string) []*keyedField {
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = normalizeColumn(f.key(true))
+	}
+	matched := make([]*keyedField, len(columns))
+	paths := map[string]bool{}
+	for i, column := range columns {
+		column = normalizeColumn(column)
+		for j := range fields {
+			if keys[j] == column && !hasMatchedParent(fields[j].path, paths) {
+				matched[i] = &fields[j]
+				paths[fields[j].path] = true
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// hasMatchedParent reports whether a parent struct of the field is matched with the column.
+func hasMatchedParent(path string, paths map[string]bool) bool {
+	for i := 0; i < len(path); i++ {
+		if path[i] == '.' && paths[path[:i]] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeColumn makes names of columns and paths of fields comparable, so Address.City field path
+// matches address_city, AddressCity and address.city columns like flattened paths of AutoRoute.
+func normalizeColumn(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", ".", "").Replace(name))
+}
+
+// scan scans the current row to the item, values of columns without fields are discarded.
+func (s *rowScanner[T]) scan(rows *sql.Rows, item *T, targets []any) error {
+	for i, f := range s.fields {
+		if f == nil {
+			targets[i] = new(any)
+			continue
+		}
+		targets[i] = f.field.GetPtr(item)
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return err
+	}
+	for _, d := range s.route.defaults {
+		d.set(item)
+	}
+	return nil
+}
+
+// MapRows maps all rows to the slice of T and closes rows, columns are matched with fields by paths like AutoRoute
+// matches flattened paths, ignoring the case and underscores, i.e. address_city column is scanned to Address.City
+// field. Columns can be matched with other fields with WithMapKey, columns without fields are discarded.
+// Fields are scanned by database/sql, so sql.Null*, sql.Scanner and pointer fields are supported.
+func MapRows[T any](rows *sql.Rows, opts ...Option) ([]T, error) {
+	items := make([]T, 0)
+	err := scanRows(rows, opts, func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items, err
+}
+
+// scanRows scans rows to items of T passed to fn, until fn returns false, and closes rows.
+func scanRows[T any](rows *sql.Rows, opts []Option, fn func(item T) bool) error {
+	defer rows.Close()
+	scanner, err := newRowScanner[T](rows, opts)
+	if err != nil {
+		return err
+	}
+	targets := make([]any, len(scanner.fields))
+	for rows.Next() {
+		var item T
+		if err = scanner.scan(rows, &item, targets); err != nil {
+			return err
+		}
+		if !fn(item) {
+			return nil
+		}
+	}
+	return rows.Err()
+}
+
+// MapArgs returns values of fields of the item matched with columns like MapRows matches them, to be passed
+// as arguments of queries, i.e. values of INSERT statements. Values are returned as they are, so database/sql
+// converts them to driver values, driver.Valuer fields included. Columns without fields return the error.
+func MapArgs[T any](item T, columns []string, opts ...Option) ([]any, error) {
+	routeName := fmt.Sprintf("%s -> []interface {}", getTypeName(item))
+	r, _, err := newStructMapRouteFor[T](routeName, opts)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]any, len(columns))
+	for i, f := range matchColumns(r.toMap, columns) {
+		if f == nil {
+			return nil, fmt.Errorf("column %s doesn't match fields, route: %s", columns[i], routeName)
+		}
+		args[i] = getFieldValue(f.field, &item)
+	}
+	return args, nil
+}
@@ -0,0 +1,180 @@
+package gomapper
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver returns rows of the table registered for the query.
+type fakeDriver struct{}
+
+type fakeTable struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+var fakeTables = map[string]fakeTable{}
+
+// fakeExecArgs are arguments of executed statements by queries.
+var fakeExecArgs = map[string][]driver.Value{}
+
+type fakeConn struct{}
+
+type fakeStmt struct {
+	query string
+}
+
+type fakeRows struct {
+	table fakeTable
+	i     int
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeExecArgs[s.query] = args
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	table, ok := fakeTables[s.query]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found", s.query)
+	}
+	return &fakeRows{table: table}, nil
+}
+
+func (r *fakeRows) Columns() []string { return r.table.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.table.rows) {
+		if r.table.err != nil {
+			return r.table.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.table.rows[r.i])
+	r.i++
+	return nil
+}
+
+func init() {
+	sql.Register("gomapper-fake", fakeDriver{})
+}
+
+// RowsStatus is the custom type scanned with sql.Scanner.
+type RowsStatus string
+
+func (s *RowsStatus) Scan(src any) error {
+	value, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unsupported status %v", src)
+	}
+	*s = RowsStatus(strings.ToUpper(value))
+	return nil
+}
+
+func (s RowsStatus) Value() (driver.Value, error) {
+	return strings.ToLower(string(s)), nil
+}
+
+type RowsAddress struct {
+	City string
+}
+
+type RowsUser struct {
+	ID        int64
+	Name      sql.NullString
+	Email     *string
+	Address   RowsAddress
+	CreatedAt time.Time
+	Status    RowsStatus
+	Score     float64
+}
+
+func queryFake(t *testing.T, table fakeTable) *sql.Rows {
+	db, err := sql.Open("gomapper-fake", "")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	fakeTables[t.Name()] = table
+	rows, err := db.Query(t.Name())
+	assert.NoError(t, err)
+	return rows
+}
+
+func TestMapRows(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	users := fakeTable{
+		columns: []string{"id", "name", "email", "address_city", "created_at", "status", "rating", "unknown"},
+		rows: [][]driver.Value{
+			{int64(1), "John", "john@example.com", "Berlin", createdAt, "active", 4.5, "x"},
+			{int64(2), nil, nil, "Paris", createdAt, "blocked", 3.0, nil},
+		},
+	}
+	email := "john@example.com"
+	expected := []RowsUser{
+		{ID: 1, Name: sql.NullString{String: "John", Valid: true}, Email: &email, Address: RowsAddress{City: "Berlin"},
+			CreatedAt: createdAt, Status: "ACTIVE", Score: 4.5},
+		{ID: 2, Address: RowsAddress{City: "Paris"}, CreatedAt: createdAt, Status: "BLOCKED", Score: 3.0},
+	}
+	scoreKey := WithMapKey[RowsUser](func(u *RowsUser) any { return &u.Score }, "rating")
+
+	t.Run("Slice", func(t *testing.T) {
+		dest, err := MapRows[RowsUser](queryFake(t, users), scoreKey)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dest)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		dest, err := MapRows[RowsUser](queryFake(t, fakeTable{columns: []string{"id"}}))
+		assert.NoError(t, err)
+		assert.Equal(t, []RowsUser{}, dest)
+	})
+	t.Run("Scan error", func(t *testing.T) {
+		dest, err := MapRows[RowsUser](queryFake(t, fakeTable{
+			columns: []string{"id", "status"},
+			rows:    [][]driver.Value{{int64(1), "active"}, {int64(2), int64(1)}},
+		}))
+		assert.ErrorContains(t, err, `name "status": unsupported status 1`)
+		assert.Equal(t, []RowsUser{{ID: 1, Status: "ACTIVE"}}, dest)
+	})
+	t.Run("Rows error", func(t *testing.T) {
+		_, err := MapRows[RowsUser](queryFake(t, fakeTable{columns: []string{"id"}, err: errors.New("connection lost")}))
+		assert.EqualError(t, err, "connection lost")
+	})
+	t.Run("Args", func(t *testing.T) {
+		columns := []string{"id", "name", "email", "address_city", "status", "rating"}
+		args, err := MapArgs(expected[0], columns, scoreKey)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{int64(1), expected[0].Name, expected[0].Email, "Berlin", RowsStatus("ACTIVE"), 4.5}, args)
+
+		db, err := sql.Open("gomapper-fake", "")
+		assert.NoError(t, err)
+		defer db.Close()
+		_, err = db.Exec(t.Name(), args...)
+		assert.NoError(t, err)
+		assert.Equal(t, []driver.Value{int64(1), "John", "john@example.com", "Berlin", "active", 4.5}, fakeExecArgs[t.Name()])
+
+		args, err = MapArgs(expected[1], []string{"name", "email"})
+		assert.NoError(t, err)
+		_, err = db.Exec(t.Name(), args...)
+		assert.NoError(t, err)
+		assert.Equal(t, []driver.Value{nil, nil}, fakeExecArgs[t.Name()])
+
+		_, err = MapArgs(expected[0], []string{"id", "unknown"})
+		assert.EqualError(t, err, "column unknown doesn't match fields, route: github.com/insei/gomapper.RowsUser -> []interface {}")
+	})
+}
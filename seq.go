@@ -0,0 +1,39 @@
+//go:build go1.23
+
+package gomapper
+
+import (
+	"database/sql"
+	"iter"
+)
+
+// MapSeq returns the sequence of elements of seq mapped to TDest with the route resolved once, the error
+// of each element is yielded with it. When the route is not found, the error is yielded once.
+// The source sequence is consumed lazily, so breaking the loop stops it.
+func MapSeq[TSource, TDest any](seq iter.Seq[TSource]) iter.Seq2[TDest, error] {
+	return func(yield func(TDest, error) bool) {
+		mapElement, err := newElementMapper[TSource, TDest]()
+		if err != nil {
+			yield(*new(TDest), err)
+			return
+		}
+		for source := range seq {
+			if !yield(mapElement(source)) {
+				return
+			}
+		}
+	}
+}
+
+// MapRowsSeq returns the sequence of rows mapped to T like MapRows. Rows are closed when the sequence
+// is consumed or the loop breaks.
+func MapRowsSeq[T any](rows *sql.Rows, opts ...Option) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := scanRows(rows, opts, func(item T) bool {
+			return yield(item, nil)
+		})
+		if err != nil {
+			yield(*new(T), err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+//go:build go1.23
+
+package gomapper
+
+import (
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapSeq(t *testing.T) {
+	assert.NoError(t, AddRoute[StreamRow, StreamRowDTO](func(source StreamRow, dest *StreamRowDTO) error {
+		if source.ID < 0 {
+			return errors.New("negative id")
+		}
+		dest.ID = source.ID
+		dest.Name = source.Name
+		return nil
+	}, WithReplace()))
+
+	t.Run("Map", func(t *testing.T) {
+		var dest []StreamRowDTO
+		for row, err := range MapSeq[StreamRow, StreamRowDTO](slices.Values(streamRows(3))) {
+			assert.NoError(t, err)
+			dest = append(dest, row)
+		}
+		assert.Equal(t, []StreamRowDTO{{ID: 0, Name: "row0"}, {ID: 1, Name: "row1"}, {ID: 2, Name: "row2"}}, dest)
+	})
+	t.Run("Pointers", func(t *testing.T) {
+		rows := []*StreamRow{{ID: 1, Name: "row1"}, nil}
+		var errs []error
+		for _, err := range MapSeq[*StreamRow, StreamRowDTO](slices.Values(rows)) {
+			errs = append(errs, err)
+		}
+		assert.NoError(t, errs[0])
+		assert.ErrorContains(t, errs[1], "source value can't be nil")
+	})
+	t.Run("Early termination", func(t *testing.T) {
+		consumed := 0
+		source := func(yield func(StreamRow) bool) {
+			for _, row := range streamRows(10) {
+				consumed++
+				if !yield(row) {
+					return
+				}
+			}
+		}
+		for row := range MapSeq[StreamRow, StreamRowDTO](source) {
+			if row.ID == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 3, consumed)
+	})
+	t.Run("Element error", func(t *testing.T) {
+		var errs []error
+		for _, err := range MapSeq[StreamRow, StreamRowDTO](slices.Values([]StreamRow{{ID: -1}, {ID: 1}})) {
+			errs = append(errs, err)
+		}
+		assert.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "negative id")
+		assert.NoError(t, errs[1])
+	})
+	t.Run("Route not found", func(t *testing.T) {
+		var errs []error
+		for _, err := range MapSeq[StreamRow, StreamUnroutedDTO](slices.Values(streamRows(3))) {
+			errs = append(errs, err)
+		}
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "route not found")
+	})
+}
+
+func TestMapRowsSeq(t *testing.T) {
+	users := fakeTable{
+		columns: []string{"id", "address_city"},
+		rows:    [][]driver.Value{{int64(1), "Berlin"}, {int64(2), "Paris"}},
+	}
+
+	t.Run("Break", func(t *testing.T) {
+		rows := queryFake(t, users)
+		var dest []RowsUser
+		for user, err := range MapRowsSeq[RowsUser](rows) {
+			assert.NoError(t, err)
+			dest = append(dest, user)
+			break
+		}
+		assert.Equal(t, []RowsUser{{ID: 1, Address: RowsAddress{City: "Berlin"}}}, dest)
+		assert.False(t, rows.Next())
+	})
+	t.Run("Rows error", func(t *testing.T) {
+		var errs []error
+		for _, err := range MapRowsSeq[RowsUser](queryFake(t, fakeTable{columns: []string{"id"}, err: errors.New("connection lost")})) {
+			errs = append(errs, err)
+		}
+		assert.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "connection lost")
+	})
+}
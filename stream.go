@@ -0,0 +1,67 @@
+package gomapper
+
+import (
+	"context"
+	"reflect"
+)
+
+// newElementMapper returns the func mapping elements of streams with the route resolved once,
+// each element is mapped like it's passed to Map.
+func newElementMapper[TSource, TDest any]() (func(source TSource) (TDest, error), error) {
+	sourceType := reflect.TypeOf((*TSource)(nil)).Elem()
+	mapFunc, err := elementRoute(sourceType, reflect.TypeOf((*TDest)(nil)))
+	if err != nil {
+		return nil, err
+	}
+	isPtr := sourceType.Kind() == reflect.Ptr
+	return func(source TSource) (TDest, error) {
+		var dest TDest
+		if isPtr {
+			if err := validateSource(source); err != nil {
+				return dest, err
+			}
+		}
+		err := mapFunc(newMapState(), source, &dest)
+		return dest, err
+	}, nil
+}
+
+// MapChan maps elements received from in to TDest with the route resolved once and sends them to the returned
+// channel. The returned channel is closed when in is closed, the context is canceled or the element fails
+// to map, the error channel receives the error of the element or of the context and is closed after that.
+func MapChan[TSource, TDest any](ctx context.Context, in <-chan TSource) (<-chan TDest, <-chan error) {
+	out := make(chan TDest)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(out)
+		mapElement, err := newElementMapper[TSource, TDest]()
+		if err != nil {
+			errc <- err
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			case source, ok := <-in:
+				if !ok {
+					return
+				}
+				dest, err := mapElement(source)
+				if err != nil {
+					errc <- err
+					return
+				}
+				select {
+				case out <- dest:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	return out, errc
+}
@@ -0,0 +1,87 @@
+package gomapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type StreamRow struct {
+	ID   int
+	Name string
+}
+
+type StreamRowDTO struct {
+	ID   int
+	Name string
+}
+
+type StreamUnroutedDTO struct {
+	ID int
+}
+
+func streamRows(n int) []StreamRow {
+	rows := make([]StreamRow, n)
+	for i := range rows {
+		rows[i] = StreamRow{ID: i, Name: fmt.Sprintf("row%d", i)}
+	}
+	return rows
+}
+
+func TestMapChan(t *testing.T) {
+	assert.NoError(t, AddRoute[StreamRow, StreamRowDTO](func(source StreamRow, dest *StreamRowDTO) error {
+		if source.ID < 0 {
+			return errors.New("negative id")
+		}
+		dest.ID = source.ID
+		dest.Name = source.Name
+		return nil
+	}, WithReplace()))
+	send := func(rows []StreamRow) <-chan StreamRow {
+		in := make(chan StreamRow, len(rows))
+		for _, row := range rows {
+			in <- row
+		}
+		close(in)
+		return in
+	}
+
+	t.Run("Map", func(t *testing.T) {
+		out, errc := MapChan[StreamRow, StreamRowDTO](context.Background(), send(streamRows(3)))
+		var dest []StreamRowDTO
+		for row := range out {
+			dest = append(dest, row)
+		}
+		assert.NoError(t, <-errc)
+		assert.Equal(t, []StreamRowDTO{{ID: 0, Name: "row0"}, {ID: 1, Name: "row1"}, {ID: 2, Name: "row2"}}, dest)
+	})
+	t.Run("Element error", func(t *testing.T) {
+		out, errc := MapChan[StreamRow, StreamRowDTO](context.Background(), send([]StreamRow{{ID: 1}, {ID: -1}, {ID: 2}}))
+		var dest []StreamRowDTO
+		for row := range out {
+			dest = append(dest, row)
+		}
+		assert.EqualError(t, <-errc, "negative id")
+		assert.Equal(t, []StreamRowDTO{{ID: 1}}, dest)
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan StreamRow)
+		out, errc := MapChan[StreamRow, StreamRowDTO](ctx, in)
+		in <- StreamRow{ID: 1}
+		assert.Equal(t, StreamRowDTO{ID: 1}, <-out)
+		cancel()
+		_, ok := <-out
+		assert.False(t, ok)
+		assert.ErrorIs(t, <-errc, context.Canceled)
+	})
+	t.Run("Route not found", func(t *testing.T) {
+		out, errc := MapChan[StreamRow, StreamUnroutedDTO](context.Background(), send(streamRows(1)))
+		_, ok := <-out
+		assert.False(t, ok)
+		assert.ErrorContains(t, <-errc, "route not found")
+	})
+}
@@ -0,0 +1,194 @@
+package gomapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/insei/fmap/v3"
+)
+
+// VirtualField is the field, which isn't stored in the struct directly, i.e. the member of the protobuf oneof
+// stored in the interface field. Auto routes match virtual fields by names like other fields.
+type VirtualField struct {
+	Name string
+	Type reflect.Type
+	// Get returns the value of the field of the struct pointer, ok is false when the field is unset.
+	Get func(structPtr any) (value any, ok bool)
+	// Set sets the value of the field of the struct pointer.
+	Set func(structPtr any, value any)
+}
+
+// StructFields changes fields of the struct type seen by auto routes.
+type StructFields struct {
+	// Hidden are paths of fields, which are neither mapped nor explained, nested fields of them are hidden too.
+	Hidden  []string
+	Virtual []VirtualField
+}
+
+// structFieldsFuncs are funcs registered with AddStructFields.
+var structFieldsFuncs []func(structType reflect.Type) (StructFields, bool)
+
+// AddStructFields registers the func returning changed fields of struct types, ok is false for types
+// which aren't changed. Fields are changed for auto routes registered after it, see protomap package, which hides
+// internals of protobuf messages and adds members of oneofs as virtual fields. Unset virtual fields aren't mapped,
+// zero values of source fields leave virtual fields unset.
+func AddStructFields(fields func(structType reflect.Type) (fields StructFields, ok bool)) {
+	structFieldsFuncs = append(structFieldsFuncs, fields)
+}
+
+// getStorage returns fmap storage of the struct pointer with fields changed by funcs registered with AddStructFields.
+func getStorage(structPtr any) (fmap.Storage, error) {
+	storage, err := fmap.GetFrom(structPtr)
+	if err != nil {
+		return nil, err
+	}
+	structType := reflect.TypeOf(structPtr).Elem()
+	var changed []StructFields
+	for _, fn := range structFieldsFuncs {
+		if fields, ok := fn(structType); ok {
+			changed = append(changed, fields)
+		}
+	}
+	if len(changed) == 0 {
+		return storage, nil
+	}
+	return newStructStorage(storage, changed)
+}
+
+// structStorage is fmap storage with hidden and virtual fields.
+type structStorage struct {
+	fmap.Storage
+	paths   []string
+	hidden  []string
+	virtual map[string]*virtualField
+}
+
+func newStructStorage(storage fmap.Storage, changed []StructFields) (*structStorage, error) {
+	s := &structStorage{Storage: storage, virtual: map[string]*virtualField{}}
+	for _, fields := range changed {
+		s.hidden = append(s.hidden, fields.Hidden...)
+	}
+	for _, path := range storage.GetAllPaths() {
+		if !s.isHidden(path) {
+			s.paths = append(s.paths, path)
+		}
+	}
+	for _, fields := range changed {
+		for _, v := range fields.Virtual {
+			if v.Name == "" || strings.Contains(v.Name, ".") || v.Type == nil || v.Get == nil || v.Set == nil {
+				return nil, fmt.Errorf("virtual field %q should have the name without dots, the type, Get and Set", v.Name)
+			}
+			if _, ok := s.Find(v.Name); ok {
+				return nil, fmt.Errorf("virtual field %s is already declared", v.Name)
+			}
+			f, err := newVirtualField(v)
+			if err != nil {
+				return nil, err
+			}
+			s.virtual[v.Name] = f
+			s.paths = append(s.paths, v.Name)
+		}
+	}
+	return s, nil
+}
+
+func (s *structStorage) isHidden(path string) bool {
+	for _, h := range s.hidden {
+		if path == h || strings.HasPrefix(path, h+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *structStorage) Find(path string) (fmap.Field, bool) {
+	if f, ok := s.virtual[path]; ok {
+		return f, true
+	}
+	if s.isHidden(path) {
+		return nil, false
+	}
+	return s.Storage.Find(path)
+}
+
+func (s *structStorage) MustFind(path string) fmap.Field {
+	f, _ := s.Find(path)
+	return f
+}
+
+func (s *structStorage) GetAllPaths() []string {
+	return s.paths
+}
+
+// virtualField is fmap field of VirtualField. The field of the holder struct describes the type of the field
+// and the destination value is mapped to the holder before it's set.
+type virtualField struct {
+	fmap.Field
+	name       string
+	holderType reflect.Type
+	get        func(structPtr any) (any, bool)
+	set        func(structPtr any, value any)
+}
+
+func newVirtualField(v VirtualField) (*virtualField, error) {
+	holderType := reflect.StructOf([]reflect.StructField{{Name: "Value", Type: v.Type}})
+	storage, err := fmap.GetFrom(reflect.New(holderType).Interface())
+	if err != nil {
+		return nil, err
+	}
+	return &virtualField{
+		Field:      storage.MustFind("Value"),
+		name:       v.Name,
+		holderType: holderType,
+		get:        v.Get,
+		set:        v.Set,
+	}, nil
+}
+
+func (f *virtualField) GetName() string {
+	return f.name
+}
+
+func (f *virtualField) GetStructPath() string {
+	return f.name
+}
+
+func (f *virtualField) isSet(obj any) bool {
+	_, ok := f.get(obj)
+	return ok
+}
+
+// Get returns the value of the field, unset fields have zero values.
+func (f *virtualField) Get(obj any) any {
+	if value, ok := f.get(obj); ok && value != nil {
+		return value
+	}
+	return reflect.Zero(f.GetType()).Interface()
+}
+
+// GetPtr returns the pointer to the copy of the value, virtual fields aren't addressable.
+func (f *virtualField) GetPtr(obj any) any {
+	ptr := reflect.New(f.GetType())
+	if value := reflect.ValueOf(f.Get(obj)); value.IsValid() {
+		ptr.Elem().Set(value)
+	}
+	return ptr.Interface()
+}
+
+func (f *virtualField) Set(obj any, val any) {
+	f.set(obj, val)
+}
+
+// setFrom maps the source field to the holder and sets the field from it, zero values leave the field unset.
+func (f *virtualField) setFrom(s *mapState, sourceFld fmap.Field, source, dest any) error {
+	if value := reflect.ValueOf(sourceFld.Get(source)); !value.IsValid() || value.IsZero() {
+		return nil
+	}
+	holder := reflect.New(f.holderType)
+	if err := setField(s, sourceFld, f.Field, source, holder.Interface()); err != nil {
+		return err
+	}
+	f.set(dest, holder.Elem().Field(0).Interface())
+	return nil
+}
@@ -0,0 +1,120 @@
+package gomapper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FieldsContact interface {
+	isFieldsContact()
+}
+
+type FieldsEmail struct {
+	Email string
+}
+
+func (FieldsEmail) isFieldsContact() {}
+
+type FieldsCall struct {
+	CalledAt time.Time
+}
+
+func (FieldsCall) isFieldsContact() {}
+
+type FieldsMessage struct {
+	revision int
+	Name     string
+	Contact  FieldsContact
+}
+
+type FieldsDate string
+
+type FieldsDomain struct {
+	Name     string
+	Email    string
+	CalledAt FieldsDate
+}
+
+func fieldsContactField[TContact FieldsContact, TValue any](name string, value func(*TContact) *TValue) VirtualField {
+	return VirtualField{
+		Name: name,
+		Type: reflect.TypeOf((*TValue)(nil)).Elem(),
+		Get: func(structPtr any) (any, bool) {
+			contact, ok := structPtr.(*FieldsMessage).Contact.(TContact)
+			if !ok {
+				return nil, false
+			}
+			return *value(&contact), true
+		},
+		Set: func(structPtr any, v any) {
+			var contact TContact
+			*value(&contact) = v.(TValue)
+			structPtr.(*FieldsMessage).Contact = contact
+		},
+	}
+}
+
+func TestAddStructFields(t *testing.T) {
+	AddStructFields(func(structType reflect.Type) (StructFields, bool) {
+		if structType != reflect.TypeOf(FieldsMessage{}) {
+			return StructFields{}, false
+		}
+		return StructFields{
+			Hidden: []string{"revision", "Contact"},
+			Virtual: []VirtualField{
+				fieldsContactField("Email", func(c *FieldsEmail) *string { return &c.Email }),
+				fieldsContactField("CalledAt", func(c *FieldsCall) *time.Time { return &c.CalledAt }),
+			},
+		}, true
+	})
+	assert.NoError(t, AddConverter(func(source time.Time) (FieldsDate, error) {
+		return FieldsDate(source.Format(time.DateOnly)), nil
+	}))
+	assert.NoError(t, AddConverter(func(source FieldsDate) (time.Time, error) {
+		return time.Parse(time.DateOnly, string(source))
+	}))
+	assert.NoError(t, AutoRouteBidirectional[FieldsMessage, FieldsDomain]())
+
+	t.Run("Map virtual fields", func(t *testing.T) {
+		dest, err := MapTo[FieldsDomain](FieldsMessage{revision: 1, Name: "John", Contact: FieldsEmail{Email: "john@example.com"}})
+		assert.NoError(t, err)
+		assert.Equal(t, FieldsDomain{Name: "John", Email: "john@example.com"}, dest)
+		dest, err = MapTo[FieldsDomain](FieldsMessage{Contact: FieldsCall{CalledAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}})
+		assert.NoError(t, err)
+		assert.Equal(t, FieldsDomain{CalledAt: "2024-01-02"}, dest)
+	})
+	t.Run("Set virtual fields", func(t *testing.T) {
+		dest, err := MapTo[FieldsMessage](FieldsDomain{Name: "John", Email: "john@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, FieldsMessage{Name: "John", Contact: FieldsEmail{Email: "john@example.com"}}, dest)
+		dest, err = MapTo[FieldsMessage](FieldsDomain{CalledAt: "2024-01-02"})
+		assert.NoError(t, err)
+		assert.Equal(t, FieldsMessage{Contact: FieldsCall{CalledAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}, dest)
+		_, err = MapTo[FieldsMessage](FieldsDomain{CalledAt: "tomorrow"})
+		assert.Error(t, err)
+	})
+	t.Run("Explain", func(t *testing.T) {
+		explanation, err := ExplainRoute[FieldsDomain, FieldsMessage]()
+		assert.NoError(t, err)
+		var paths []string
+		for _, f := range explanation.Fields {
+			paths = append(paths, f.DestPath)
+			assert.NotEqual(t, FieldMappingUnmapped, f.Mapping, f.DestPath)
+		}
+		assert.Equal(t, []string{"Name", "Email", "CalledAt"}, paths)
+	})
+	t.Run("Invalid virtual field", func(t *testing.T) {
+		AddStructFields(func(structType reflect.Type) (StructFields, bool) {
+			return StructFields{Virtual: []VirtualField{{Name: "Name", Type: reflect.TypeOf("")}}},
+				structType == reflect.TypeOf(FieldsDomain{})
+		})
+		defer func() {
+			structFieldsFuncs = structFieldsFuncs[:len(structFieldsFuncs)-1]
+		}()
+		err := AutoRoute[FieldsDomain, FieldsMessage](WithReplace())
+		assert.ErrorContains(t, err, "virtual field \"Name\" should have the name without dots, the type, Get and Set")
+	})
+}
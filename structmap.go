@@ -0,0 +1,530 @@
+package gomapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/insei/fmap/v3"
+)
+
+// keyedField is the struct field stored in maps of map routes.
+type keyedField struct {
+	field fmap.Field
+	path  string
+	// keys are keys of the field and its parents
+	keys    []string
+	renamed bool
+	// nested is the struct field with nested fields, stored as the nested map
+	nested bool
+	// ptr is the route of the struct pointed by the field, pointers to structs are stored as nested maps
+	ptr *structMapRoute
+}
+
+// key returns the key of the field in its parent map, or the dotted key in the root map.
+func (f keyedField) key(dotted bool) string {
+	if dotted {
+		return strings.Join(f.keys, ".")
+	}
+	return f.keys[len(f.keys)-1]
+}
+
+// structMapRoute is the configuration of map routes resolved on registration.
+type structMapRoute struct {
+	structType reflect.Type
+	// toMap are fields stored to maps, fromMap are fields read from maps
+	toMap    []keyedField
+	fromMap  []keyedField
+	dotted   bool
+	defaults []fieldDefault
+}
+
+// AutoMapRoute registers routes from TStruct to map[string]any and from map[string]any to TStruct, fields are
+// stored with their names as keys, fields of nested structs are stored as nested maps or with dotted keys,
+// see WithDottedKeys. Pointers to structs are stored as nested maps too, nil pointers as nil values.
+// Keys can be changed with WithMapKey, WithFieldRename stores the source field with the key of the destination
+// path in place of the destination field. Fields skipped with WithFieldSkip are not mapped in both directions,
+// fields skipped with WithDestFieldSkip are not read from maps. Values of other types than the field type
+// are mapped with routes and converters registered between their types, elements of []any values, like
+// slices decoded from JSON, are mapped to elements of slice fields. Whole float64 numbers, like numbers decoded
+// from JSON, are mapped to integer fields without converters, numbers with fractions or out of the range
+// of the field type return an error.
+func AutoMapRoute[TStruct any](opts ...Option) error {
+	callSite := getCallSite(1)
+	r, opt, err := newStructMapRouteOf[TStruct](reflect.TypeOf(map[string]any{}), opts)
+	if err != nil {
+		return err
+	}
+	toMapFunc := func(s *mapState, source TStruct, dest *map[string]any) error {
+		if *dest == nil {
+			*dest = map[string]any{}
+		}
+		// fields are read through the pointer, fmap can't read fields of structs stored directly in interfaces
+		return r.storeFields(s, &source, *dest)
+	}
+	err = addRoute[TStruct, map[string]any](toMapFunc, &route{
+		info: RouteInfo{Kind: RouteKindMap, CallSite: callSite, Fields: r.fieldsInfo(r.toMap, false)},
+	}, opt.Replace)
+	if err != nil {
+		return err
+	}
+	fromMapFunc := func(s *mapState, source map[string]any, dest *TStruct) error {
+		if err := s.enter(); err != nil {
+			return err
+		}
+		defer s.exit()
+		return r.readFields(s, source, dest)
+	}
+	return addRoute[map[string]any, TStruct](fromMapFunc, &route{
+		info: RouteInfo{Kind: RouteKindMap, CallSite: callSite, Fields: r.fieldsInfo(r.fromMap, true)},
+	}, opt.Replace)
+}
+
+// newStructMapRouteOf resolves options of map routes of TStruct and checks that the route from mapType
+// to TStruct can be registered.
+func newStructMapRouteOf[TStruct any](mapType reflect.Type, opts []Option) (*structMapRoute, *options, error) {
+	routeName := fmt.Sprintf("%s -> %s", getTypeName(*new(TStruct)), getTypeNameRecursive(mapType, ""))
+	r, opt, err := newStructMapRouteFor[TStruct](routeName, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the route from the map is checked before the registration of other routes to avoid partial registration
+	if err = checkRoute(mapType, reflect.TypeOf((*TStruct)(nil)), opt.Replace); err != nil {
+		return nil, nil, err
+	}
+	return r, opt, nil
+}
+
+// newStructMapRouteFor resolves options of map routes of TStruct, routeName is used in errors.
+func newStructMapRouteFor[TStruct any](routeName string, opts []Option) (*structMapRoute, *options, error) {
+	s := new(TStruct)
+	structType := reflect.TypeOf(s).Elem()
+	storage, err := fmap.GetFrom(s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w, route: %s", err, routeName)
+	}
+	opt := &options{}
+	for _, o := range opts {
+		o.apply(opt)
+	}
+	if err = opt.validate(structType, structType); err != nil {
+		return nil, nil, fmt.Errorf("%w, route: %s", err, routeName)
+	}
+	if unsupported := opt.notMapRouteOptions(); len(unsupported) > 0 {
+		return nil, nil, fmt.Errorf("options are not supported by map routes: %s, route: %s",
+			strings.Join(unsupported, ", "), routeName)
+	}
+	return newStructMapRoute(storage, structType, opt), opt, nil
+}
+
+// notMapRouteOptions describes options which can't be applied to map routes.
+func (o *options) notMapRouteOptions() []string {
+	var unsupported []string
+	if len(o.Fns) > 0 {
+		unsupported = append(unsupported, "WithFunc")
+	}
+	if len(o.Conditions) > 0 {
+		unsupported = append(unsupported, "WithCondition")
+	}
+	if len(o.Resolvers) > 0 {
+		unsupported = append(unsupported, "WithFieldResolver")
+	}
+	if len(o.Bases) > 0 {
+		unsupported = append(unsupported, "IncludeBase")
+	}
+	if o.Reverse {
+		unsupported = append(unsupported, "ReverseMap")
+	}
+	if o.Depth != nil {
+		unsupported = append(unsupported, "WithMaxDepth")
+	}
+	return unsupported
+}
+
+func newStructMapRoute(storage fmap.Storage, structType reflect.Type, opt *options) *structMapRoute {
+	return buildStructMapRoute(storage, structType, opt, map[reflect.Type]*structMapRoute{})
+}
+
+// buildStructMapRoute resolves fields of the struct, routes are routes of structs pointed by fields, shared
+// by recursive types.
+func buildStructMapRoute(storage fmap.Storage, structType reflect.Type, opt *options,
+	routes map[reflect.Type]*structMapRoute) *structMapRoute {
+	r := &structMapRoute{structType: structType, dotted: opt.DottedKeys, defaults: opt.Defaults}
+	// unexported fields are not stored, so structs with unexported fields only, like time.Time, are stored as values
+	var paths, unexported []string
+	for _, path := range storage.GetAllPaths() {
+		fld, _ := storage.Find(path)
+		if !fld.IsExported() {
+			unexported = append(unexported, path)
+		} else if !isPathUnder(path, unexported) {
+			paths = append(paths, path)
+		}
+	}
+	var renameSources []fieldSelector
+	for _, rn := range opt.Renames {
+		renameSources = append(renameSources, rn.source)
+	}
+	keys := map[string][]string{"": nil}
+	renamed := map[string]bool{}
+	for _, path := range paths {
+		// the field at the renamed path is replaced by the renamed field, like AutoRoute doesn't match it by name
+		if isFieldSelected(path, opt.Excluded) || opt.isRenameTarget(path) && !isFieldSelected(path, renameSources) {
+			continue
+		}
+		fld, _ := findField(storage, path)
+		parent := ""
+		if i := strings.LastIndex(path, "."); i >= 0 {
+			parent = path[:i]
+		}
+		parentKeys, key := keys[parent], fld.GetName()
+		renamed[path] = renamed[parent]
+		for _, rn := range opt.Renames {
+			if rn.source.path == path {
+				// the destination path is the key of the renamed field
+				destKeys := strings.Split(rn.dest.path, ".")
+				parentKeys, key = destKeys[:len(destKeys)-1], destKeys[len(destKeys)-1]
+				renamed[path] = true
+			}
+		}
+		for _, k := range opt.MapKeys {
+			if k.field.path == path {
+				key = k.key
+				renamed[path] = true
+			}
+		}
+		keys[path] = append(append([]string(nil), parentKeys...), key)
+		f := keyedField{field: fld, path: path, keys: keys[path], renamed: renamed[path]}
+		for _, p := range paths {
+			if strings.HasPrefix(p, path+".") {
+				f.nested = true
+				break
+			}
+		}
+		if typ := fld.GetType(); typ.Kind() == reflect.Ptr && hasExportedFields(typ.Elem()) {
+			f.ptr = pointedStructMapRoute(typ.Elem(), opt, routes)
+		}
+		r.toMap = append(r.toMap, f)
+		if !isFieldSelected(path, opt.DestExcluded) {
+			r.fromMap = append(r.fromMap, f)
+		}
+	}
+	return r
+}
+
+// pointedStructMapRoute returns the route of the struct pointed by fields, options of the route
+// aren't applied to fields of pointed structs except of dotted keys.
+func pointedStructMapRoute(structType reflect.Type, opt *options, routes map[reflect.Type]*structMapRoute) *structMapRoute {
+	if r, ok := routes[structType]; ok {
+		return r
+	}
+	storage, err := fmap.GetFrom(reflect.New(structType).Interface())
+	if err != nil {
+		return nil
+	}
+	r := &structMapRoute{}
+	routes[structType] = r
+	*r = *buildStructMapRoute(storage, structType, &options{DottedKeys: opt.DottedKeys}, routes)
+	return r
+}
+
+// hasExportedFields reports whether the type is the struct with exported fields.
+func hasExportedFields(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *structMapRoute) fieldsInfo(fields []keyedField, fromMap bool) []RouteField {
+	infos := make([]RouteField, 0, len(fields))
+	for _, f := range fields {
+		if f.nested {
+			continue
+		}
+		info := RouteField{SourcePath: f.path, DestPath: strings.Join(f.keys, "."), Match: FieldMatchName}
+		if f.renamed {
+			info.Match = FieldMatchRename
+		}
+		if fromMap {
+			info.SourcePath, info.DestPath = info.DestPath, info.SourcePath
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// storeFields stores fields of the source struct pointer to the map.
+func (r *structMapRoute) storeFields(s *mapState, source any, dest map[string]any) error {
+	for _, f := range r.toMap {
+		parent := dest
+		if !r.dotted {
+			parent = nestedMap(dest, f.keys[:len(f.keys)-1])
+		}
+		if f.nested {
+			if !r.dotted {
+				nestedMap(parent, f.keys[len(f.keys)-1:])
+			}
+			continue
+		}
+		value := getFieldValue(f.field, source)
+		if f.ptr == nil {
+			parent[f.key(r.dotted)] = value
+			continue
+		}
+		if err := r.storePointed(s, f, value, parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storePointed stores the struct pointed by the field as the nested map, or with dotted keys,
+// so the map doesn't share the struct with the source. Nil pointers are stored as nil.
+func (r *structMapRoute) storePointed(s *mapState, f keyedField, value any, parent map[string]any) error {
+	key := f.key(r.dotted)
+	ptr := reflect.ValueOf(value)
+	if ptr.IsNil() {
+		parent[key] = nil
+		return nil
+	}
+	nested := map[string]any{}
+	err := s.mapField(strings.Join(f.keys, "."), &nested, func() error {
+		if err := s.enter(); err != nil {
+			return err
+		}
+		defer s.exit()
+		return f.ptr.storeFields(s, value, nested)
+	})
+	if err != nil {
+		return err
+	}
+	if nested == nil || !r.dotted {
+		parent[key] = nested
+		return nil
+	}
+	for k, v := range nested {
+		parent[key+"."+k] = v
+	}
+	return nil
+}
+
+// nestedMap returns the nested map stored by keys, missing maps are created.
+func nestedMap(m map[string]any, keys []string) map[string]any {
+	for _, key := range keys {
+		nested, ok := m[key].(map[string]any)
+		if !ok {
+			nested = map[string]any{}
+			m[key] = nested
+		}
+		m = nested
+	}
+	return m
+}
+
+// readFields sets fields of the destination struct pointer from values of the map, fields without values are not set.
+func (r *structMapRoute) readFields(s *mapState, source map[string]any, dest any) error {
+	// set are paths of struct fields set as a whole or missing in the map, their nested fields are not read
+	var set []string
+	for _, f := range r.fromMap {
+		if isPathUnder(f.path, set) {
+			continue
+		}
+		value, ok := r.lookup(source, f)
+		if f.ptr != nil {
+			if nested := r.lookupPointed(source, f, value, ok); nested != nil {
+				set = append(set, f.path)
+				if err := r.readPointed(s, f, nested, dest); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !ok && f.nested && r.dotted {
+			// nested fields are read by their dotted keys
+			continue
+		}
+		if !ok || value == nil {
+			set = append(set, f.path)
+			continue
+		}
+		if _, isMap := value.(map[string]any); isMap && f.nested && !r.dotted {
+			route, err := findRoute(reflect.TypeOf(value), reflect.PointerTo(f.field.GetType()))
+			if err != nil {
+				return err
+			}
+			if route == nil {
+				// nested fields are read from the nested map
+				continue
+			}
+		}
+		set = append(set, f.path)
+		if err := r.setValue(s, f, value, dest); err != nil {
+			return err
+		}
+	}
+	for _, d := range r.defaults {
+		d.set(dest)
+	}
+	return nil
+}
+
+// lookup returns the value of the field from the map, values of nested fields are read from nested maps.
+func (r *structMapRoute) lookup(source map[string]any, f keyedField) (any, bool) {
+	if r.dotted {
+		value, ok := source[f.key(true)]
+		return value, ok
+	}
+	m := source
+	for _, key := range f.keys[:len(f.keys)-1] {
+		nested, ok := m[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		m = nested
+	}
+	value, ok := m[f.key(false)]
+	return value, ok
+}
+
+// lookupPointed returns the nested map of the struct pointed by the field, with dotted keys the nested map
+// is collected from keys prefixed with the key of the field. It returns nil when there are no values.
+func (r *structMapRoute) lookupPointed(source map[string]any, f keyedField, value any, ok bool) map[string]any {
+	if ok {
+		nested, _ := value.(map[string]any)
+		return nested
+	}
+	if !r.dotted {
+		return nil
+	}
+	var nested map[string]any
+	prefix := f.key(true) + "."
+	for k, v := range source {
+		if strings.HasPrefix(k, prefix) {
+			if nested == nil {
+				nested = map[string]any{}
+			}
+			nested[strings.TrimPrefix(k, prefix)] = v
+		}
+	}
+	return nested
+}
+
+// readPointed sets the field to the new struct read from the nested map.
+func (r *structMapRoute) readPointed(s *mapState, f keyedField, nested map[string]any, dest any) error {
+	destPtr := f.field.GetPtr(dest)
+	return s.mapField(strings.Join(f.keys, "."), destPtr, func() error {
+		if err := s.enter(); err != nil {
+			return err
+		}
+		defer s.exit()
+		ptr := reflect.New(f.ptr.structType)
+		if err := f.ptr.readFields(s, nested, ptr.Interface()); err != nil {
+			return err
+		}
+		reflect.ValueOf(destPtr).Elem().Set(ptr)
+		return nil
+	})
+}
+
+func (r *structMapRoute) setValue(s *mapState, f keyedField, value any, dest any) error {
+	key := strings.Join(f.keys, ".")
+	destPtr := f.field.GetPtr(dest)
+	return s.mapField(key, destPtr, func() error {
+		return r.convertValue(s, key, value, reflect.ValueOf(destPtr))
+	})
+}
+
+// convertValue sets the value pointed by ptr to the value of the key, values of other types are mapped with
+// registered routes, whole float64 numbers are set to integers, elements of []any, like slices decoded from JSON,
+// are converted to elements of slices.
+func (r *structMapRoute) convertValue(s *mapState, key string, value any, ptr reflect.Value) error {
+	typ := ptr.Type().Elem()
+	valueOf := reflect.ValueOf(value)
+	if valueOf.Type().AssignableTo(typ) {
+		ptr.Elem().Set(valueOf)
+		return nil
+	}
+	route, err := findRoute(valueOf.Type(), ptr.Type())
+	if err != nil {
+		return err
+	}
+	if route != nil {
+		return s.mapValue(value, ptr.Interface())
+	}
+	if f, ok := value.(float64); ok && isIntegerKind(typ.Kind()) {
+		// numbers decoded from JSON are float64
+		if !setWholeFloat(f, ptr.Elem()) {
+			return fmt.Errorf("value %v of key %s can't be mapped to %s without loss, route: %s -> %s", f, key,
+				getTypeNameRecursive(typ, ""), getTypeName(map[string]any{}), getTypeNameRecursive(r.structType, ""))
+		}
+		return nil
+	}
+	items, ok := value.([]any)
+	if !ok || typ.Kind() != reflect.Slice {
+		return fmt.Errorf("type mismatch of key %s: %s can't be mapped to %s, route: %s -> %s", key,
+			getTypeName(value), getTypeNameRecursive(typ, ""),
+			getTypeName(map[string]any{}), getTypeNameRecursive(r.structType, ""))
+	}
+	slice := reflect.MakeSlice(typ, len(items), len(items))
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		elemPtr := slice.Index(i).Addr()
+		err = s.mapElement(i, elemPtr.Interface(), func() error {
+			return r.convertValue(s, fmt.Sprintf("%s[%d]", key, i), item, elemPtr)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	ptr.Elem().Set(slice)
+	return nil
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+// setWholeFloat sets the integer value to the whole number, it returns false when the number has the fraction
+// or doesn't fit the integer type.
+func setWholeFloat(f float64, dest reflect.Value) bool {
+	switch dest.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := int64(f)
+		if float64(i) != f || dest.OverflowInt(i) {
+			return false
+		}
+		dest.SetInt(i)
+	default:
+		if f < 0 {
+			return false
+		}
+		u := uint64(f)
+		if float64(u) != f || dest.OverflowUint(u) {
+			return false
+		}
+		dest.SetUint(u)
+	}
+	return true
+}
+
+func isPathUnder(path string, parents []string) bool {
+	for _, p := range parents {
+		if strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package gomapper
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MapRouteAddress struct {
+	City   string
+	Street string
+}
+
+type MapRouteUser struct {
+	ID        int
+	Name      string
+	Password  string
+	Address   MapRouteAddress
+	CreatedAt time.Time
+	Tags      []string
+	Score     float64
+}
+
+type MapRouteDottedUser struct {
+	ID      int
+	Address MapRouteAddress
+}
+
+type MapRoutePointerUser struct {
+	Name    string
+	Tags    []string
+	Address *MapRouteAddress
+	Manager *MapRoutePointerUser
+}
+
+type MapRouteDottedPointerUser struct {
+	ID      int
+	Address *MapRouteAddress
+}
+
+type MapRouteStatus string
+
+type MapRouteRenamedOrder struct {
+	Code   string
+	Number string
+	Status MapRouteStatus
+}
+
+type MapRouteOrder struct {
+	Code   string
+	Amount int
+	Status string
+}
+
+func TestAutoMapRoute(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := MapRouteUser{
+		ID:        1,
+		Name:      "John",
+		Password:  "secret",
+		Address:   MapRouteAddress{City: "Berlin", Street: "Main"},
+		CreatedAt: createdAt,
+		Tags:      []string{"admin"},
+		Score:     1.5,
+	}
+	assert.NoError(t, AutoMapRoute[MapRouteUser](
+		WithFieldSkip[MapRouteUser](func(u *MapRouteUser) any { return &u.Password }),
+		WithMapKey[MapRouteUser](func(u *MapRouteUser) any { return &u.Name }, "name"),
+		WithMapKey[MapRouteUser](func(u *MapRouteUser) any { return &u.Address }, "address"),
+	))
+	assert.NoError(t, AutoMapRoute[MapRouteDottedUser](WithDottedKeys()))
+	assert.NoError(t, AutoMapRoute[MapRouteOrder](
+		WithDefault[MapRouteOrder](func(o *MapRouteOrder) *string { return &o.Status }, "new"),
+	))
+
+	t.Run("Struct to map", func(t *testing.T) {
+		dest, err := MapTo[map[string]any](user)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"ID":        1,
+			"name":      "John",
+			"address":   map[string]any{"City": "Berlin", "Street": "Main"},
+			"CreatedAt": createdAt,
+			"Tags":      []string{"admin"},
+			"Score":     1.5,
+		}, dest)
+	})
+	t.Run("Map to struct", func(t *testing.T) {
+		dest, err := MapTo[MapRouteUser](map[string]any{
+			"ID":        1,
+			"name":      "John",
+			"Password":  "secret",
+			"address":   map[string]any{"City": "Berlin"},
+			"CreatedAt": createdAt,
+			"Unknown":   true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, MapRouteUser{ID: 1, Name: "John", Address: MapRouteAddress{City: "Berlin"}, CreatedAt: createdAt}, dest)
+	})
+	t.Run("Round trip", func(t *testing.T) {
+		m, err := MapTo[map[string]any](&user)
+		assert.NoError(t, err)
+		dest, err := MapTo[MapRouteUser](m)
+		assert.NoError(t, err)
+		expected := user
+		expected.Password = ""
+		assert.Equal(t, expected, dest)
+	})
+	t.Run("Dotted keys", func(t *testing.T) {
+		source := MapRouteDottedUser{ID: 2, Address: MapRouteAddress{City: "Paris", Street: "Rue"}}
+		m, err := MapTo[map[string]any](source)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"ID": 2, "Address.City": "Paris", "Address.Street": "Rue"}, m)
+		dest, err := MapTo[MapRouteDottedUser](m)
+		assert.NoError(t, err)
+		assert.Equal(t, source, dest)
+	})
+	t.Run("Type mismatch", func(t *testing.T) {
+		_, err := MapTo[MapRouteUser](map[string]any{"address": map[string]any{"City": 1}})
+		assert.EqualError(t, err, "type mismatch of key address.City: int can't be mapped to string, "+
+			"route: map[string]interface {} -> github.com/insei/gomapper.MapRouteUser")
+		_, err = MapTo[MapRouteUser](map[string]any{"address": "Berlin"})
+		assert.ErrorContains(t, err, "type mismatch of key address: string can't be mapped to github.com/insei/gomapper.MapRouteAddress")
+	})
+	t.Run("Converters", func(t *testing.T) {
+		assert.NoError(t, AddConverter[float64, int](func(source float64) (int, error) {
+			return int(math.Round(source)), nil
+		}, WithReplace()))
+		defer RemoveRoute[float64, int]()
+		dest, err := MapTo[MapRouteOrder](map[string]any{"Code": "A1", "Amount": 9.6})
+		assert.NoError(t, err)
+		assert.Equal(t, MapRouteOrder{Code: "A1", Amount: 10, Status: "new"}, dest)
+	})
+	t.Run("Whole numbers", func(t *testing.T) {
+		var m map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(`{"Code": "A1", "Amount": 10}`), &m))
+		dest, err := MapTo[MapRouteOrder](m)
+		assert.NoError(t, err)
+		assert.Equal(t, MapRouteOrder{Code: "A1", Amount: 10, Status: "new"}, dest)
+		_, err = MapTo[MapRouteOrder](map[string]any{"Amount": 10.5})
+		assert.EqualError(t, err, "value 10.5 of key Amount can't be mapped to int without loss, "+
+			"route: map[string]interface {} -> github.com/insei/gomapper.MapRouteOrder")
+		_, err = MapTo[MapRouteOrder](map[string]any{"Amount": 1e300})
+		assert.ErrorContains(t, err, "value 1e+300 of key Amount can't be mapped to int without loss")
+	})
+	t.Run("Slices", func(t *testing.T) {
+		dest, err := MapTo[[]map[string]any]([]MapRouteOrder{{Code: "A1"}, {Code: "A2"}})
+		assert.NoError(t, err)
+		assert.Len(t, dest, 2)
+		assert.Equal(t, "A2", dest[1]["Code"])
+	})
+	t.Run("Unsupported options", func(t *testing.T) {
+		err := AutoMapRoute[MapRouteOrder](WithReplace(), WithFunc[MapRouteOrder, MapRouteOrder](func(MapRouteOrder, *MapRouteOrder) {}))
+		assert.ErrorContains(t, err, "options are not supported by map routes: WithFunc")
+	})
+	t.Run("Route info", func(t *testing.T) {
+		var infos []RouteInfo
+		for _, info := range Routes() {
+			if info.Kind == RouteKindMap && (info.Source == reflect.TypeOf(MapRouteDottedUser{}) || info.Dest == reflect.TypeOf(MapRouteDottedUser{})) {
+				infos = append(infos, info)
+			}
+		}
+		assert.Len(t, infos, 2)
+		assert.Equal(t, RouteField{SourcePath: "Address.City", DestPath: "Address.City", Match: FieldMatchName}, infos[0].Fields[1])
+		assert.Equal(t, "map", infos[0].Kind.String())
+	})
+	t.Run("Pointers", func(t *testing.T) {
+		assert.NoError(t, AutoMapRoute[MapRoutePointerUser]())
+		assert.NoError(t, AutoMapRoute[MapRouteDottedPointerUser](WithDottedKeys()))
+		source := MapRoutePointerUser{
+			Name:    "John",
+			Address: &MapRouteAddress{City: "Berlin"},
+			Manager: &MapRoutePointerUser{Name: "Jane"},
+		}
+		m, err := MapTo[map[string]any](source)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"Name":    "John",
+			"Tags":    []string(nil),
+			"Address": map[string]any{"City": "Berlin", "Street": ""},
+			"Manager": map[string]any{"Name": "Jane", "Tags": []string(nil), "Address": nil, "Manager": nil},
+		}, m)
+		dest, err := MapTo[MapRoutePointerUser](m)
+		assert.NoError(t, err)
+		assert.Equal(t, source, dest)
+		assert.NotSame(t, source.Address, dest.Address)
+
+		dotted := MapRouteDottedPointerUser{ID: 1, Address: &MapRouteAddress{City: "Paris"}}
+		m, err = MapTo[map[string]any](dotted)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"ID": 1, "Address.City": "Paris", "Address.Street": ""}, m)
+		dottedDest, err := MapTo[MapRouteDottedPointerUser](m)
+		assert.NoError(t, err)
+		assert.Equal(t, dotted, dottedDest)
+		m, err = MapTo[map[string]any](MapRouteDottedPointerUser{ID: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"ID": 2, "Address": nil}, m)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var m map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(`{
+			"Name": "John",
+			"Tags": ["admin", "dev"],
+			"Address": {"City": "Berlin"},
+			"Manager": {"Name": "Jane", "Address": null}
+		}`), &m))
+		dest, err := MapTo[MapRoutePointerUser](m)
+		assert.NoError(t, err)
+		assert.Equal(t, MapRoutePointerUser{
+			Name:    "John",
+			Tags:    []string{"admin", "dev"},
+			Address: &MapRouteAddress{City: "Berlin"},
+			Manager: &MapRoutePointerUser{Name: "Jane"},
+		}, dest)
+
+		assert.NoError(t, json.Unmarshal([]byte(`{"Tags": ["admin", 1]}`), &m))
+		_, err = MapTo[MapRoutePointerUser](m)
+		assert.EqualError(t, err, "type mismatch of key Tags[1]: float64 can't be mapped to string, "+
+			"route: map[string]interface {} -> github.com/insei/gomapper.MapRoutePointerUser")
+	})
+	t.Run("Renames", func(t *testing.T) {
+		assert.NoError(t, AutoMapRoute[MapRouteRenamedOrder](WithFieldRename[MapRouteRenamedOrder, MapRouteRenamedOrder](
+			func(o *MapRouteRenamedOrder) any { return &o.Code },
+			func(o *MapRouteRenamedOrder) any { return &o.Number },
+		)))
+		m, err := MapTo[map[string]any](MapRouteRenamedOrder{Code: "A1", Number: "1", Status: "new"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"Number": "A1", "Status": MapRouteStatus("new")}, m)
+		dest, err := MapTo[MapRouteRenamedOrder](m)
+		assert.NoError(t, err)
+		assert.Equal(t, MapRouteRenamedOrder{Code: "A1", Status: "new"}, dest)
+	})
+}
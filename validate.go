@@ -0,0 +1,195 @@
+package gomapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RouteProblem is the problem of the registered route found by Validate.
+type RouteProblem struct {
+	Route RouteInfo
+	// Sample is the sample source the route failed on: "zero" or "populated", empty for unmapped fields.
+	Sample string
+	// Err is the error returned by the route or the recovered panic.
+	Err error
+	// UnmappedFields are paths of destination fields of the auto route, which are not mapped.
+	UnmappedFields []string
+}
+
+func (p RouteProblem) String() string {
+	route := fmt.Sprintf("%s route %s -> %s registered at %s", p.Route.Kind,
+		getTypeNameRecursive(p.Route.Source, ""), getTypeNameRecursive(p.Route.Dest, ""), p.Route.CallSite)
+	if p.Err != nil {
+		return fmt.Sprintf("%s: %s sample: %v", route, p.Sample, p.Err)
+	}
+	return fmt.Sprintf("%s: unmapped destination fields: %s", route, strings.Join(p.UnmappedFields, ", "))
+}
+
+// ValidationError is returned from Validate with problems of all registered routes.
+type ValidationError struct {
+	Problems []RouteProblem
+}
+
+func (e *ValidationError) Error() string {
+	b := &strings.Builder{}
+	_, _ = fmt.Fprintf(b, "%d route problems found:", len(e.Problems))
+	for _, p := range e.Problems {
+		b.WriteString("\n\t")
+		b.WriteString(p.String())
+	}
+	return b.String()
+}
+
+// Validate runs every registered route, including generated routes between slices, with the zero value
+// and the populated sample of the source type and reports errors and panics of routes as well as unmapped
+// destination fields of auto routes. Validate is intended to be called on startup after all routes are registered.
+// Routes from interfaces are not run, because the sample of the interface type can't be created.
+func Validate() error {
+	var problems []RouteProblem
+	for _, r := range sortedRoutes() {
+		if r.info.Source.Kind() == reflect.Interface {
+			if r.auto != nil {
+				problems = appendUnmapped(problems, r)
+			}
+			continue
+		}
+		samples := []struct {
+			name  string
+			value reflect.Value
+		}{
+			{name: "zero", value: newZeroSample(r.info.Source)},
+			{name: "populated", value: newPopulatedSample(r.info.Source)},
+		}
+		for _, sample := range samples {
+			if err := runSample(r, sample.value); err != nil {
+				problems = append(problems, RouteProblem{Route: r.info, Sample: sample.name, Err: err})
+			}
+		}
+		if r.auto != nil {
+			problems = appendUnmapped(problems, r)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
+
+func appendUnmapped(problems []RouteProblem, r *route) []RouteProblem {
+	if unmapped := r.auto.unmappedFields(); len(unmapped) > 0 {
+		problems = append(problems, RouteProblem{Route: r.info, UnmappedFields: unmapped})
+	}
+	return problems
+}
+
+func runSample(r *route, source reflect.Value) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return r.mapFunc(newMapState(), source.Interface(), reflect.New(r.info.Dest).Interface())
+}
+
+// newZeroSample returns the zero value of the type, pointers point to zero values.
+func newZeroSample(typ reflect.Type) reflect.Value {
+	if typ.Kind() == reflect.Ptr {
+		return reflect.New(typ.Elem())
+	}
+	return reflect.Zero(typ)
+}
+
+// newPopulatedSample returns the value of the type with all exported fields, slices and maps populated.
+func newPopulatedSample(typ reflect.Type) reflect.Value {
+	value := reflect.New(typ).Elem()
+	populate(value, map[reflect.Type]bool{})
+	return value
+}
+
+// populate sets sample values, structs are not populated recursively to support self-referential types.
+func populate(v reflect.Value, visiting map[reflect.Type]bool) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("sample")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(1)
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		populate(elem.Elem(), visiting)
+		v.Set(elem)
+	case reflect.Slice:
+		slice := reflect.MakeSlice(v.Type(), 1, 1)
+		populate(slice.Index(0), visiting)
+		v.Set(slice)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			populate(v.Index(i), visiting)
+		}
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		populate(key, visiting)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		populate(elem, visiting)
+		m := reflect.MakeMap(v.Type())
+		m.SetMapIndex(key, elem)
+		v.Set(m)
+	case reflect.Struct:
+		if visiting[v.Type()] {
+			return
+		}
+		visiting[v.Type()] = true
+		defer delete(visiting, v.Type())
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				populate(v.Field(i), visiting)
+			}
+		}
+	}
+}
+
+// unmappedFields returns paths of destination fields which are not mapped, fields of structs mapped
+// as a whole and nested fields of unmapped structs are not included.
+func (r *autoRoute) unmappedFields() []string {
+	var explanations []FieldExplanation
+	for _, destPath := range r.destStorage.GetAllPaths() {
+		explanations = append(explanations, r.explainField(destPath))
+	}
+	isUnmapped := func(e FieldExplanation) bool {
+		return (e.Mapping == FieldMappingUnmapped || e.Mapping == FieldMappingTypeMismatch) && !e.Default
+	}
+	covers := func(e FieldExplanation) bool {
+		if e.Mapping == FieldMappingNested {
+			return e.SourcePath != e.DestPath
+		}
+		return !isUnmapped(e)
+	}
+	var unmapped []string
+	for _, e := range explanations {
+		if !isUnmapped(e) {
+			continue
+		}
+		ok := true
+		for _, other := range explanations {
+			// the field is mapped by the parent struct, it's a part of the unmapped parent struct
+			// or it's a struct with mapped nested fields
+			if strings.HasPrefix(e.DestPath, other.DestPath+".") && (covers(other) || isUnmapped(other)) ||
+				strings.HasPrefix(other.DestPath, e.DestPath+".") && !isUnmapped(other) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			unmapped = append(unmapped, e.DestPath)
+		}
+	}
+	return unmapped
+}
@@ -0,0 +1,101 @@
+package gomapper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ValidateNested struct {
+	City string
+}
+
+type ValidateSource struct {
+	Name   string
+	Age    int
+	Tags   []string
+	Nested ValidateNested
+	Child  *ValidateSource
+}
+
+type ValidateDest struct {
+	Name    string
+	Age     int
+	Tags    []string
+	Nested  ValidateNested
+	Missing string
+	Other   ValidateNested
+}
+
+type ValidatePanicSource struct {
+	Items []string
+}
+
+type ValidateErrorSource struct {
+	Name string
+}
+
+type ValidateValidSource struct {
+	Name string
+}
+
+func findProblems(err error, source reflect.Type) []RouteProblem {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	var problems []RouteProblem
+	for _, p := range validationErr.Problems {
+		if p.Route.Source == source {
+			problems = append(problems, p)
+		}
+	}
+	return problems
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, AutoRoute[ValidateSource, ValidateDest]())
+	assert.NoError(t, AddRoute[ValidatePanicSource, ValidateDest](func(source ValidatePanicSource, dest *ValidateDest) error {
+		dest.Name = source.Items[0]
+		return nil
+	}))
+	assert.NoError(t, AddRoute[ValidateErrorSource, ValidateDest](func(source ValidateErrorSource, dest *ValidateDest) error {
+		if source.Name != "" {
+			return errors.New("invalid name")
+		}
+		return nil
+	}))
+	assert.NoError(t, AddRoute[ValidateValidSource, ValidateDest](func(source ValidateValidSource, dest *ValidateDest) error {
+		dest.Name = source.Name
+		return nil
+	}))
+	err := Validate()
+	assert.Error(t, err)
+
+	t.Run("Unmapped fields", func(t *testing.T) {
+		problems := findProblems(err, reflect.TypeOf(ValidateSource{}))
+		assert.Len(t, problems, 1)
+		assert.Equal(t, []string{"Missing", "Other"}, problems[0].UnmappedFields)
+		assert.Contains(t, err.Error(), "unmapped destination fields: Missing, Other")
+	})
+	t.Run("Panic", func(t *testing.T) {
+		problems := findProblems(err, reflect.TypeOf(ValidatePanicSource{}))
+		assert.Len(t, problems, 1)
+		assert.Equal(t, "zero", problems[0].Sample)
+		assert.ErrorContains(t, problems[0].Err, "panic")
+	})
+	t.Run("Error", func(t *testing.T) {
+		problems := findProblems(err, reflect.TypeOf(ValidateErrorSource{}))
+		assert.Len(t, problems, 1)
+		assert.Equal(t, "populated", problems[0].Sample)
+		assert.ErrorContains(t, problems[0].Err, "invalid name")
+		problems = findProblems(err, reflect.TypeOf([]ValidateErrorSource{}))
+		assert.NotEmpty(t, problems)
+	})
+	t.Run("Valid route", func(t *testing.T) {
+		assert.Empty(t, findProblems(err, reflect.TypeOf(ValidateValidSource{})))
+		assert.Empty(t, findProblems(err, reflect.TypeOf([]ValidateValidSource{})))
+	})
+}
@@ -0,0 +1,210 @@
+package gomapper
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	stringType      = reflect.TypeOf("")
+	unmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// AutoValuesRoute registers routes from url.Values and map[string][]string to TStruct for query strings and forms.
+// Keys are paths of fields matched case-insensitively, i.e. address.city sets Address.City field, keys which differ
+// only in the case are reported as conflicting. Structs pointed by fields are allocated when there are keys of their
+// fields. Keys can be changed with WithMapKey and fields can be skipped with WithFieldSkip or WithDestFieldSkip.
+// Values are converted with converters from string, encoding.TextUnmarshaler or parsed to scalar types, repeated
+// keys set slice fields. Empty values leave fields of other types than string zero. Errors of all invalid keys
+// are returned joined.
+func AutoValuesRoute[TStruct any](opts ...Option) error {
+	callSite := getCallSite(1)
+	r, opt, err := newStructMapRouteOf[TStruct](reflect.TypeOf(url.Values{}), opts)
+	if err != nil {
+		return err
+	}
+	if err = checkRoute(reflect.TypeOf(map[string][]string{}), reflect.TypeOf((*TStruct)(nil)), opt.Replace); err != nil {
+		return err
+	}
+	readValues := func(s *mapState, source map[string][]string, dest *TStruct) error {
+		if errs := r.readValues(s, newValuesIndex(source), "", dest); len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+		for _, d := range r.defaults {
+			d.set(dest)
+		}
+		return nil
+	}
+	info := RouteInfo{Kind: RouteKindMap, CallSite: callSite, Fields: r.fieldsInfo(r.fromMap, true)}
+	err = addRoute[url.Values, TStruct](func(s *mapState, source url.Values, dest *TStruct) error {
+		return readValues(s, source, dest)
+	}, &route{info: info}, opt.Replace)
+	if err != nil {
+		return err
+	}
+	return addRoute[map[string][]string, TStruct](readValues, &route{info: info}, opt.Replace)
+}
+
+// valuesIndex is the index of keys of values by their lower case keys.
+type valuesIndex struct {
+	source map[string][]string
+	keys   map[string][]string
+}
+
+func newValuesIndex(source map[string][]string) valuesIndex {
+	index := valuesIndex{source: source, keys: make(map[string][]string, len(source))}
+	for key := range source {
+		lower := strings.ToLower(key)
+		index.keys[lower] = append(index.keys[lower], key)
+	}
+	for _, keys := range index.keys {
+		sort.Strings(keys)
+	}
+	return index
+}
+
+// hasPrefix reports whether there are values with keys starting with the prefix.
+func (i valuesIndex) hasPrefix(prefix string) bool {
+	for lower, keys := range i.keys {
+		if strings.HasPrefix(lower, prefix) {
+			for _, key := range keys {
+				if len(i.source[key]) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// readValues sets fields of the struct pointer from values of keys with the prefix, structs pointed by fields
+// are allocated when there are values of their fields. Keys which differ only in the case conflict.
+func (r *structMapRoute) readValues(s *mapState, index valuesIndex, prefix string, dest any) []error {
+	var errs []error
+	for _, f := range r.fromMap {
+		lower := prefix + strings.ToLower(f.key(true))
+		if f.ptr != nil && index.hasPrefix(lower+".") {
+			ptr := reflect.New(f.ptr.structType)
+			nestedErrs := f.ptr.readValues(s, index, lower+".", ptr.Interface())
+			errs = append(errs, nestedErrs...)
+			if len(nestedErrs) == 0 {
+				reflect.ValueOf(f.field.GetPtr(dest)).Elem().Set(ptr)
+			}
+			continue
+		}
+		keys := index.keys[lower]
+		if len(keys) > 1 {
+			errs = append(errs, fmt.Errorf("conflicting keys %s", strings.Join(keys, ", ")))
+			continue
+		}
+		if len(keys) == 0 || f.nested || len(index.source[keys[0]]) == 0 {
+			continue
+		}
+		if err := r.setValues(s, f, index.source[keys[0]], dest); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value of key %s: %w", keys[0], err))
+		}
+	}
+	return errs
+}
+
+// setValues sets the field from values of its key, slice fields are set from all values, other fields
+// from the first value.
+func (r *structMapRoute) setValues(s *mapState, f keyedField, values []string, dest any) error {
+	destPtr := reflect.ValueOf(f.field.GetPtr(dest))
+	fieldType := f.field.GetType()
+	if fieldType.Kind() != reflect.Slice || isParsedAsWhole(fieldType) {
+		return s.mapField(f.path, destPtr.Interface(), func() error {
+			return parseValue(s, values[0], destPtr)
+		})
+	}
+	slice := reflect.MakeSlice(fieldType, len(values), len(values))
+	for i, value := range values {
+		if err := parseValue(s, value, slice.Index(i).Addr()); err != nil {
+			return err
+		}
+	}
+	destPtr.Elem().Set(slice)
+	return nil
+}
+
+// isParsedAsWhole reports whether the slice type is parsed from the single value.
+func isParsedAsWhole(typ reflect.Type) bool {
+	if reflect.PointerTo(typ).Implements(unmarshalerType) {
+		return true
+	}
+	r, _ := findRoute(stringType, reflect.PointerTo(typ))
+	return r != nil
+}
+
+// parseValue sets the value pointed by ptr from the string.
+func parseValue(s *mapState, value string, ptr reflect.Value) error {
+	typ := ptr.Type().Elem()
+	if typ == stringType {
+		ptr.Elem().SetString(value)
+		return nil
+	}
+	r, err := findRoute(stringType, ptr.Type())
+	if err != nil {
+		return err
+	}
+	if r != nil {
+		return s.mapValue(value, ptr.Interface())
+	}
+	if value == "" {
+		return nil
+	}
+	if u, ok := ptr.Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+	switch typ.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(typ.Elem())
+		if err = parseValue(s, value, elem); err != nil {
+			return err
+		}
+		ptr.Elem().Set(elem)
+	case reflect.String:
+		ptr.Elem().SetString(value)
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		ptr.Elem().SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		ptr.Elem().SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		ptr.Elem().SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return err
+		}
+		ptr.Elem().SetFloat(f)
+	default:
+		return fmt.Errorf("%s can't be parsed from the string", getTypeNameRecursive(typ, ""))
+	}
+	return nil
+}
+
+// parseBool parses the boolean value, "on" is the value of checked checkboxes in forms.
+func parseBool(value string) (bool, error) {
+	if value == "on" {
+		return true, nil
+	}
+	return strconv.ParseBool(value)
+}
@@ -0,0 +1,97 @@
+package gomapper
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ValuesAddress struct {
+	City string
+	Zip  int
+}
+
+type ValuesStatus int
+
+type ValuesRequest struct {
+	Query    string
+	Page     int
+	Limit    *uint
+	Price    float64
+	Active   bool
+	IDs      []int64
+	Address  ValuesAddress
+	Owner    *ValuesAddress
+	Since    time.Time
+	Status   ValuesStatus
+	Internal string
+	Sort     string
+}
+
+func TestAutoValuesRoute(t *testing.T) {
+	assert.NoError(t, AutoValuesRoute[ValuesRequest](
+		WithFieldSkip[ValuesRequest](func(r *ValuesRequest) any { return &r.Internal }),
+		WithMapKey[ValuesRequest](func(r *ValuesRequest) any { return &r.Query }, "q"),
+		WithDefault[ValuesRequest](func(r *ValuesRequest) *string { return &r.Sort }, "name"),
+	))
+	assert.NoError(t, AddConverter[string, ValuesStatus](func(source string) (ValuesStatus, error) {
+		if source == "active" {
+			return 1, nil
+		}
+		return 0, nil
+	}))
+
+	t.Run("Query", func(t *testing.T) {
+		query, err := url.ParseQuery("q=shoes&page=2&limit=10&price=9.5&active=on&ids=1&ids=2" +
+			"&address.city=Berlin&Address.Zip=10115&owner.city=Paris&since=2024-01-02T03:04:05Z&status=active&internal=x&unknown=1")
+		assert.NoError(t, err)
+		dest, err := MapTo[ValuesRequest](query)
+		assert.NoError(t, err)
+		limit := uint(10)
+		assert.Equal(t, ValuesRequest{
+			Query:   "shoes",
+			Page:    2,
+			Limit:   &limit,
+			Price:   9.5,
+			Active:  true,
+			IDs:     []int64{1, 2},
+			Address: ValuesAddress{City: "Berlin", Zip: 10115},
+			Owner:   &ValuesAddress{City: "Paris"},
+			Since:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Status:  1,
+			Sort:    "name",
+		}, dest)
+	})
+	t.Run("Map of strings", func(t *testing.T) {
+		dest, err := MapTo[ValuesRequest](map[string][]string{"page": {"3", "4"}, "sort": {"price"}})
+		assert.NoError(t, err)
+		assert.Equal(t, ValuesRequest{Page: 3, Sort: "price"}, dest)
+	})
+	t.Run("Empty values", func(t *testing.T) {
+		dest, err := MapTo[ValuesRequest](url.Values{"page": {""}, "q": {""}})
+		assert.NoError(t, err)
+		assert.Equal(t, ValuesRequest{Sort: "name"}, dest)
+	})
+	t.Run("Invalid values", func(t *testing.T) {
+		_, err := MapTo[ValuesRequest](url.Values{"page": {"two"}, "address.zip": {"-"}, "ids": {"1", "x"}})
+		assert.Error(t, err)
+		lines := strings.Split(err.Error(), "\n")
+		assert.Equal(t, []string{
+			`invalid value of key page: strconv.ParseInt: parsing "two": invalid syntax`,
+			`invalid value of key ids: strconv.ParseInt: parsing "x": invalid syntax`,
+			`invalid value of key address.zip: strconv.ParseInt: parsing "-": invalid syntax`,
+		}, lines)
+	})
+	t.Run("Conflicting keys", func(t *testing.T) {
+		_, err := MapTo[ValuesRequest](url.Values{"page": {"1"}, "Page": {"2"}, "q": {"a"}})
+		assert.EqualError(t, err, "conflicting keys Page, page")
+		_, err = MapTo[ValuesRequest](url.Values{"owner.city": {"Paris"}, "Owner.City": {"Rome"}})
+		assert.EqualError(t, err, "conflicting keys Owner.City, owner.city")
+	})
+	t.Run("Registered twice", func(t *testing.T) {
+		assert.ErrorContains(t, AutoValuesRoute[ValuesRequest](), "route already registered")
+	})
+}
@@ -0,0 +1,218 @@
+package gomapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// wrapperType describes the family of optional wrapper types, i.e. all sql.NullX types or all instantiations
+// of the generic Optional[T].
+type wrapperType interface {
+	// elem returns the type of the value wrapped by the type, ok is false when the type is not the wrapper.
+	elem(typ reflect.Type) (elem reflect.Type, ok bool)
+	// unwrap returns the wrapped value, ok is false when the value is not set.
+	unwrap(wrapper reflect.Value) (value reflect.Value, ok bool)
+	// wrap returns the wrapper of the type with the value set.
+	wrap(typ reflect.Type, value reflect.Value) reflect.Value
+}
+
+// sqlNullWrapper is the wrapper type of sql.Null[T] and sql.NullX types, the first field is the value and
+// the second one is Valid.
+type sqlNullWrapper struct{}
+
+func (sqlNullWrapper) elem(typ reflect.Type) (reflect.Type, bool) {
+	if typ.PkgPath() != "database/sql" || !strings.HasPrefix(typ.Name(), "Null") || typ.Kind() != reflect.Struct ||
+		typ.NumField() != 2 || typ.Field(1).Name != "Valid" || typ.Field(1).Type.Kind() != reflect.Bool {
+		return nil, false
+	}
+	return typ.Field(0).Type, true
+}
+
+func (sqlNullWrapper) unwrap(wrapper reflect.Value) (reflect.Value, bool) {
+	return wrapper.Field(0), wrapper.Field(1).Bool()
+}
+
+func (sqlNullWrapper) wrap(typ reflect.Type, value reflect.Value) reflect.Value {
+	wrapper := reflect.New(typ).Elem()
+	wrapper.Field(0).Set(value)
+	wrapper.Field(1).SetBool(true)
+	return wrapper
+}
+
+// methodWrapper is the wrapper type with the getter method returning the value and whether it's set
+// and the setter method of the pointer receiver.
+type methodWrapper struct {
+	pkgPath string
+	// name is the name of the type without type arguments, so all instantiations of the generic type match it
+	name   string
+	getter string
+	setter string
+}
+
+func (w methodWrapper) elem(typ reflect.Type) (reflect.Type, bool) {
+	name, _, _ := strings.Cut(typ.Name(), "[")
+	if typ.PkgPath() != w.pkgPath || name != w.name {
+		return nil, false
+	}
+	getter, ok := typ.MethodByName(w.getter)
+	if !ok || getter.Type.NumIn() != 1 || getter.Type.NumOut() != 2 || getter.Type.Out(1).Kind() != reflect.Bool {
+		return nil, false
+	}
+	setter, ok := reflect.PointerTo(typ).MethodByName(w.setter)
+	if !ok || setter.Type.NumIn() != 2 || setter.Type.In(1) != getter.Type.Out(0) {
+		return nil, false
+	}
+	return getter.Type.Out(0), true
+}
+
+func (w methodWrapper) unwrap(wrapper reflect.Value) (reflect.Value, bool) {
+	out := wrapper.MethodByName(w.getter).Call(nil)
+	return out[0], out[1].Bool()
+}
+
+func (w methodWrapper) wrap(typ reflect.Type, value reflect.Value) reflect.Value {
+	wrapper := reflect.New(typ)
+	wrapper.MethodByName(w.setter).Call([]reflect.Value{value})
+	return wrapper.Elem()
+}
+
+var wrapperTypes = []wrapperType{sqlNullWrapper{}}
+
+// wrapperMapFunc maps the source value to the settable destination value.
+type wrapperMapFunc func(s *mapState, source reflect.Value, dest reflect.Value) error
+
+// AddWrapper registers the optional wrapper type, which is mapped from and to the wrapped value and the pointer to it,
+// unset wrappers are mapped to zero values and nil pointers, nil pointers are mapped to unset wrappers and values,
+// zero values included, are mapped to set wrappers.
+// The value is read with the getter method returning the value and whether it's set, and set with the setter method
+// of the pointer receiver. All instantiations of the generic wrapper type are registered at once, i.e. Optional[any]
+// registers Optional[string], Optional[int] and others. sql.Null[T] and sql.NullX types are registered by default.
+func AddWrapper[TWrapper any](getter, setter string) error {
+	typ := reflect.TypeOf((*TWrapper)(nil)).Elem()
+	name, _, _ := strings.Cut(typ.Name(), "[")
+	if name == "" {
+		return fmt.Errorf("wrapper type should be a named type, but has %s type", getTypeNameRecursive(typ, ""))
+	}
+	w := methodWrapper{pkgPath: typ.PkgPath(), name: name, getter: getter, setter: setter}
+	if _, ok := w.elem(typ); !ok {
+		return fmt.Errorf("wrapper type %s should have %s() (T, bool) method and %s(T) method of the pointer receiver",
+			getTypeNameRecursive(typ, ""), getter, setter)
+	}
+	wrapperTypes = append(wrapperTypes, w)
+	clearResolvedRoutes()
+	return nil
+}
+
+func findWrapper(typ reflect.Type) (wrapperType, reflect.Type, bool) {
+	for _, w := range wrapperTypes {
+		if elem, ok := w.elem(typ); ok {
+			return w, elem, true
+		}
+	}
+	return nil, nil, false
+}
+
+// findWrapperRoute returns the built-in route between the wrapper type and its value or the pointer to it,
+// or between wrapper types with convertible values.
+func findWrapperRoute(sourceType, destPtrType reflect.Type) *route {
+	destType := destPtrType.Elem()
+	if sourceType == destType {
+		return nil
+	}
+	if sourceType.Kind() == reflect.Ptr {
+		if _, _, ok := findWrapper(sourceType.Elem()); ok {
+			// the dereferenced source is mapped
+			return findWrapperRoute(sourceType.Elem(), destPtrType)
+		}
+	}
+	var mapFunc wrapperMapFunc
+	if sw, sourceElem, ok := findWrapper(sourceType); ok {
+		mapFunc = newUnwrapFunc(sw, sourceElem, destType)
+	} else if dw, destElem, ok := findWrapper(destType); ok {
+		mapFunc = newWrapFunc(dw, sourceType, destElem, destType)
+	}
+	if mapFunc == nil {
+		return nil
+	}
+	return &route{
+		mapFunc: func(s *mapState, source any, dest any) error {
+			return mapFunc(s, reflect.ValueOf(source), reflect.ValueOf(dest).Elem())
+		},
+		info: RouteInfo{Source: sourceType, Dest: destType, Kind: RouteKindConverter, CallSite: "builtin"},
+	}
+}
+
+// newUnwrapFunc returns the func mapping the wrapper to the value, the pointer to the value or another wrapper.
+func newUnwrapFunc(w wrapperType, elem, destType reflect.Type) wrapperMapFunc {
+	targetType, build := destType, func(target reflect.Value) reflect.Value { return target }
+	if dw, destElem, ok := findWrapper(destType); ok {
+		targetType = destElem
+		build = func(target reflect.Value) reflect.Value { return dw.wrap(destType, target) }
+	} else if destType.Kind() == reflect.Ptr && newValueConverter(elem, destType) == nil {
+		targetType = destType.Elem()
+		build = func(target reflect.Value) reflect.Value { return target.Addr() }
+	}
+	convert := newValueConverter(elem, targetType)
+	if convert == nil {
+		return nil
+	}
+	return func(s *mapState, source reflect.Value, dest reflect.Value) error {
+		value, ok := w.unwrap(source)
+		if !ok {
+			dest.SetZero()
+			return nil
+		}
+		target := reflect.New(targetType).Elem()
+		if err := convert(s, value, target); err != nil {
+			return err
+		}
+		dest.Set(build(target))
+		return nil
+	}
+}
+
+// newWrapFunc returns the func mapping the value or the pointer to the value to the wrapper.
+func newWrapFunc(w wrapperType, sourceType, elem, destType reflect.Type) wrapperMapFunc {
+	valueType := sourceType
+	if sourceType.Kind() == reflect.Ptr && newValueConverter(sourceType, elem) == nil {
+		valueType = sourceType.Elem()
+	}
+	convert := newValueConverter(valueType, elem)
+	if convert == nil {
+		return nil
+	}
+	return func(s *mapState, source reflect.Value, dest reflect.Value) error {
+		if valueType != sourceType {
+			if source.IsNil() {
+				dest.SetZero()
+				return nil
+			}
+			source = source.Elem()
+		}
+		value := reflect.New(elem).Elem()
+		if err := convert(s, source, value); err != nil {
+			return err
+		}
+		dest.Set(w.wrap(destType, value))
+		return nil
+	}
+}
+
+// newValueConverter returns the func setting the value of the source type to the destination value,
+// nil when there is neither assignment nor route between types.
+func newValueConverter(sourceType, destType reflect.Type) wrapperMapFunc {
+	if sourceType.AssignableTo(destType) {
+		return func(_ *mapState, source reflect.Value, dest reflect.Value) error {
+			dest.Set(source)
+			return nil
+		}
+	}
+	r, err := findRoute(sourceType, reflect.PointerTo(destType))
+	if err != nil || r == nil {
+		return nil
+	}
+	return func(s *mapState, source reflect.Value, dest reflect.Value) error {
+		return r.mapFunc(s, source.Interface(), dest.Addr().Interface())
+	}
+}
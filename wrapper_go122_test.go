@@ -0,0 +1,36 @@
+//go:build go1.22
+
+package gomapper
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type GenericWrapperEntity struct {
+	Rank  sql.Null[int]
+	Level sql.Null[string]
+}
+
+type GenericWrapperDTO struct {
+	Rank  int
+	Level *string
+}
+
+func TestGenericSQLNullWrapper(t *testing.T) {
+	assert.NoError(t, AutoRouteBidirectional[GenericWrapperEntity, GenericWrapperDTO]())
+	level := "senior"
+	entity := GenericWrapperEntity{Rank: sql.Null[int]{V: 5, Valid: true}, Level: sql.Null[string]{V: level, Valid: true}}
+
+	dest, err := MapTo[GenericWrapperDTO](entity)
+	assert.NoError(t, err)
+	assert.Equal(t, GenericWrapperDTO{Rank: 5, Level: &level}, dest)
+	reverse, err := MapTo[GenericWrapperEntity](dest)
+	assert.NoError(t, err)
+	assert.Equal(t, entity, reverse)
+	reverse, err = MapTo[GenericWrapperEntity](GenericWrapperDTO{})
+	assert.NoError(t, err)
+	assert.Equal(t, GenericWrapperEntity{Rank: sql.Null[int]{Valid: true}}, reverse)
+}
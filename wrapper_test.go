@@ -0,0 +1,119 @@
+package gomapper
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+func (o *Optional[T]) Set(value T) {
+	o.value = value
+	o.set = true
+}
+
+type WrapperEntity struct {
+	Name      sql.NullString
+	Age       sql.NullInt64
+	DeletedAt sql.NullTime
+	Rank      sql.NullInt32
+	Score     sql.NullFloat64
+	Nickname  Optional[string]
+	Level     Optional[int64]
+}
+
+type WrapperDTO struct {
+	Name      *string
+	Age       *int64
+	DeletedAt *time.Time
+	Rank      int32
+	Score     float64
+	Nickname  *string
+	Level     sql.NullInt64
+}
+
+func TestWrappers(t *testing.T) {
+	assert.NoError(t, AddWrapper[Optional[any]]("Get", "Set"))
+	assert.NoError(t, AutoRouteBidirectional[WrapperEntity, WrapperDTO]())
+	name := "John"
+	age := int64(30)
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	nickname := "jo"
+	entity := WrapperEntity{
+		Name:      sql.NullString{String: name, Valid: true},
+		Age:       sql.NullInt64{Int64: age, Valid: true},
+		DeletedAt: sql.NullTime{Time: deletedAt, Valid: true},
+		Rank:      sql.NullInt32{Int32: 5, Valid: true},
+		Score:     sql.NullFloat64{Float64: 1.5, Valid: true},
+	}
+	entity.Nickname.Set(nickname)
+	entity.Level.Set(2)
+	dto := WrapperDTO{
+		Name:      &name,
+		Age:       &age,
+		DeletedAt: &deletedAt,
+		Rank:      5,
+		Score:     1.5,
+		Nickname:  &nickname,
+		Level:     sql.NullInt64{Int64: 2, Valid: true},
+	}
+
+	t.Run("Set values", func(t *testing.T) {
+		dest, err := MapTo[WrapperDTO](entity)
+		assert.NoError(t, err)
+		assert.Equal(t, dto, dest)
+	})
+	t.Run("Unset values", func(t *testing.T) {
+		dest, err := MapTo[WrapperDTO](WrapperEntity{})
+		assert.NoError(t, err)
+		assert.Equal(t, WrapperDTO{}, dest)
+	})
+	t.Run("Reverse", func(t *testing.T) {
+		dest, err := MapTo[WrapperEntity](dto)
+		assert.NoError(t, err)
+		assert.Equal(t, entity, dest)
+		dest, err = MapTo[WrapperEntity](WrapperDTO{})
+		assert.NoError(t, err)
+		assert.Equal(t, WrapperEntity{Rank: sql.NullInt32{Valid: true}, Score: sql.NullFloat64{Valid: true}}, dest)
+	})
+	t.Run("Map", func(t *testing.T) {
+		dest, err := MapTo[string](sql.NullString{String: name, Valid: true})
+		assert.NoError(t, err)
+		assert.Equal(t, name, dest)
+		nullName, err := MapTo[sql.NullString](&name)
+		assert.NoError(t, err)
+		assert.Equal(t, sql.NullString{String: name, Valid: true}, nullName)
+		nullName, err = MapTo[sql.NullString]("")
+		assert.NoError(t, err)
+		assert.Equal(t, sql.NullString{Valid: true}, nullName)
+		namePtr, err := MapTo[*string](sql.NullString{String: name, Valid: true})
+		assert.NoError(t, err)
+		assert.Equal(t, &name, namePtr)
+		optional, err := MapTo[Optional[string]](&sql.NullString{String: name, Valid: true})
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Name.String, optional.value)
+	})
+	t.Run("Converted values", func(t *testing.T) {
+		assert.NoError(t, AddConverter[int64, string](func(source int64) (string, error) {
+			return "30", nil
+		}))
+		defer RemoveRoute[int64, string]()
+		dest, err := MapTo[string](sql.NullInt64{Int64: 30, Valid: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "30", dest)
+	})
+	t.Run("Invalid wrapper", func(t *testing.T) {
+		assert.ErrorContains(t, AddWrapper[Optional[any]]("Value", "Set"), "should have Value() (T, bool) method")
+		assert.ErrorContains(t, AddWrapper[[]string]("Get", "Set"), "should be a named type")
+	})
+}